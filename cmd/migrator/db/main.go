@@ -4,11 +4,7 @@ import (
 	"database/sql"
 	"errors"
 
-	"github.com/phungvandat/example-go/cmd/migrator/config"
-)
-
-const (
-	dbTypePostgres = "postgres"
+	"github.com/phungvandat/example-go/config"
 )
 
 var (
@@ -19,12 +15,12 @@ var (
 
 // Connector interface for open a db connection with config
 type Connector interface {
-	Open(config.Config) (*sql.DB, error)
+	Open(cfg *config.Config) (*sql.DB, error)
 }
 
 // NewConnection open new db connection using config
 func NewConnection(cfg *config.Config) (*sql.DB, error) {
-	if cfg.DBType == dbTypePostgres {
+	if cfg.DBType == config.DBTypePostgres {
 		return NewPGConnector().Open(cfg)
 	}
 
@@ -38,7 +34,7 @@ type ModelInitiator interface {
 
 // InitModel from config
 func InitModel(cfg *config.Config) error {
-	if cfg.DBType == dbTypePostgres {
+	if cfg.DBType == config.DBTypePostgres {
 		return NewPGConnector().InitModel(cfg)
 	}
 
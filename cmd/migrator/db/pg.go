@@ -2,51 +2,34 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/lib/pq" // driver for open postgres connection
 
-	"github.com/phungvandat/example-go/cmd/migrator/config"
+	"github.com/phungvandat/example-go/config"
 	"github.com/phungvandat/example-go/domain"
 )
 
 // PGConnector store implement open for postgres
 type PGConnector struct{}
 
+// PGConnector is checked to implement interfaces of connectors at compile time
+var (
+	_ Connector      = (*PGConnector)(nil)
+	_ ModelInitiator = (*PGConnector)(nil)
+)
+
 // NewPGConnector create new PGConnector
 func NewPGConnector() *PGConnector { return &PGConnector{} }
 
 // Open open new connection to posgres using config
 func (c *PGConnector) Open(cfg *config.Config) (*sql.DB, error) {
-	sslmode := "disable"
-	if cfg.DBSSLModeOption == "enable" {
-		sslmode = "require"
-	}
-
-	dbstring := fmt.Sprintf("user=%s dbname=%s sslmode=%s password=%s host=%s port=%s",
-		cfg.DBUserName,
-		cfg.DBName,
-		sslmode,
-		cfg.DBPassword,
-		cfg.DBHostname,
-		cfg.DBPort,
-	)
-
-	return sql.Open("postgres", dbstring)
+	return sql.Open("postgres", cfg.DataSource())
 }
 
 // InitModel .
 func (c PGConnector) InitModel(cfg *config.Config) error {
-
-	connectionString := fmt.Sprintf("host = %s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.DBHostname,
-		cfg.DBPort,
-		cfg.DBUserName,
-		cfg.DBPassword,
-		cfg.DBName,
-	)
-	db, err := gorm.Open("postgres", connectionString)
+	db, err := gorm.Open("postgres", cfg.DataSource())
 	if err != nil {
 		return err
 	}
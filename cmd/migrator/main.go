@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"flag"
 	"fmt"
 	"log"
@@ -9,24 +10,50 @@ import (
 	"github.com/pressly/goose"
 
 	// Init DB drivers.
-	"github.com/phungvandat/example-go/cmd/migrator/config"
 	dbconn "github.com/phungvandat/example-go/cmd/migrator/db"
+	"github.com/phungvandat/example-go/config"
 )
 
 var (
-	flags = flag.NewFlagSet("migrator", flag.ExitOnError)
-	dir   = flags.String("dir", "cmd/migrator/migration", "directory with migration files")
+	flags  = flag.NewFlagSet("migrator", flag.ExitOnError)
+	loader = config.NewLoader(flags, configFile())
+	// dir is kept for scripts which were written before -migration-dir
+	dir = flags.String("dir", "", "alias of -migration-dir")
 )
 
+// configFile return file of configuration, there is none on production
+func configFile() string {
+	if os.Getenv("PRODUCTION") == "true" {
+		return ""
+	}
+	return ".env_migrator.yaml"
+}
+
 func main() {
 	flags.Usage = usage
 	flags.Parse(os.Args[1:])
 
 	args := flags.Args()
 
+	cfg, err := loader.Load()
+	if err != nil {
+		log.Fatalf("can't read config by error: %v", err)
+	}
+	if *dir != "" && !isFlagSet("migration-dir") {
+		cfg.MigrationDir = *dir
+	}
+
+	if loader.PrintRequested() {
+		cfg.Print(os.Stdout)
+		return
+	}
+	if err := cfg.ValidateMigrator(); err != nil {
+		log.Fatal(err)
+	}
+
 	// create migrations
 	if len(args) > 1 && args[0] == "create" {
-		if err := goose.Run("create", nil, *dir, args[1:]...); err != nil {
+		if err := goose.Run("create", nil, cfg.MigrationDir, args[1:]...); err != nil {
 			log.Fatalf("migrator run: %v", err)
 		}
 		return
@@ -42,11 +69,6 @@ func main() {
 		return
 	}
 
-	cfg, err := config.GetBy(config.NewFileReader(".env_migrator", "."))
-	if err != nil {
-		log.Fatalf("can't read config by error: %v", err)
-	}
-
 	db, err := dbconn.NewConnection(cfg)
 	if err != nil {
 		log.Fatalf("can't connect to db by error: %v", err)
@@ -66,11 +88,51 @@ func main() {
 		return
 	}
 
-	if err := goose.Run(command, db, *dir, arguments...); err != nil {
+	// the first admin can not be made by API, only an admin grants roles
+	if args[0] == "promote-admin" {
+		if len(args) != 2 {
+			flags.Usage()
+			return
+		}
+		if err := promoteAdmin(db, args[1]); err != nil {
+			log.Fatalf("Failed to promote admin by error: %v", err)
+		}
+		return
+	}
+
+	if err := goose.Run(command, db, cfg.MigrationDir, arguments...); err != nil {
 		log.Fatalf("migrator run: %v", err)
 	}
 }
 
+// isFlagSet report flag of name is given
+func isFlagSet(name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// promoteAdmin make the user of email an admin
+func promoteAdmin(db *sql.DB, email string) error {
+	res, err := db.Exec(`UPDATE users SET role = 'admin'
+		WHERE lower(email) = lower($1) AND deleted_at IS NULL`, email)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("no user has email %q", email)
+	}
+	return nil
+}
+
 func usage() {
 	fmt.Print(usagePrefix)
 	flags.PrintDefaults()
@@ -85,6 +147,9 @@ Examples:
     migrator create add_some_column sql
     migrator create fetch_user_data go
     migrator up
+    migrator promote-admin admin@example.com
+    migrator -migration-dir db/migration status
+    migrator -print-config
 Options:
 `
 
@@ -99,5 +164,6 @@ Commands:
     status               Dump the migration status for the current DB
     version              Print the current version of the database
     create NAME [sql|go] Creates new migration file with next version
+    promote-admin EMAIL  Make the user of EMAIL an admin
 `
 )
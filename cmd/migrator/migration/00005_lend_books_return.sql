@@ -0,0 +1,17 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+ALTER TABLE "public"."lend_books"
+  ADD COLUMN "returned_at" timestamptz,
+  ADD COLUMN "status" text NOT NULL DEFAULT 'active';
+
+-- only lend books which are not returned hold their book
+CREATE INDEX "lend_books_active_book_id_idx" ON "public"."lend_books" ("book_id") WHERE "returned_at" IS NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX "public"."lend_books_active_book_id_idx";
+ALTER TABLE "public"."lend_books"
+  DROP COLUMN "returned_at",
+  DROP COLUMN "status";
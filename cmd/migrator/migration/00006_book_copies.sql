@@ -0,0 +1,42 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE "public"."book_copies" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  "deleted_at" timestamptz,
+  "book_id" uuid NOT NULL,
+  "barcode" text NOT NULL,
+  "condition" text NOT NULL DEFAULT 'new',
+  "shelf_location" text,
+  "acquired_at" timestamptz DEFAULT now(),
+  UNIQUE("barcode"),
+  CONSTRAINT "book_copies_pkey" PRIMARY KEY ("id"),
+  FOREIGN KEY ("book_id") REFERENCES "public"."books"("id")
+) WITH (oids = false);
+
+ALTER TABLE "public"."lend_books"
+  ADD COLUMN "book_copy_id" uuid REFERENCES "public"."book_copies"("id");
+
+-- every existing book gets one copy, which takes over the lend books of that book
+INSERT INTO "public"."book_copies" ("id", "book_id", "barcode", "acquired_at")
+SELECT md5(random()::text || clock_timestamp()::text || "id"::text)::uuid, "id", 'LEGACY-' || "id"::text, "created_at"
+FROM "public"."books";
+
+UPDATE "public"."lend_books" l
+SET "book_copy_id" = c."id"
+FROM "public"."book_copies" c
+WHERE c."book_id" = l."book_id";
+
+-- a copy is held by at most one lend book which is not returned
+DROP INDEX "public"."lend_books_active_book_id_idx";
+CREATE UNIQUE INDEX "lend_books_active_book_copy_id_idx" ON "public"."lend_books" ("book_copy_id")
+  WHERE "returned_at" IS NULL AND "deleted_at" IS NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX "public"."lend_books_active_book_copy_id_idx";
+CREATE INDEX "lend_books_active_book_id_idx" ON "public"."lend_books" ("book_id") WHERE "returned_at" IS NULL;
+ALTER TABLE "public"."lend_books" DROP COLUMN "book_copy_id";
+DROP TABLE "public"."book_copies";
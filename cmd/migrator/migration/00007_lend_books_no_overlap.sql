@@ -0,0 +1,40 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- a copy may have several lend books (bookings) which are not returned,
+-- as long as their time ranges do not overlap
+DROP INDEX "public"."lend_books_active_book_copy_id_idx";
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- lend books stored before range validation may end before they start,
+-- they must be fixed by hand since their real range is unknown
+-- +goose StatementBegin
+DO $$
+DECLARE
+  invalid bigint;
+BEGIN
+  SELECT count(*) INTO invalid FROM "public"."lend_books" WHERE "to" <= "from";
+  IF invalid > 0 THEN
+    RAISE EXCEPTION '% lend books end before they start, fix "to" of them and migrate again', invalid
+      USING HINT = 'SELECT id, "from", "to" FROM lend_books WHERE "to" <= "from";';
+  END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE "public"."lend_books"
+  ADD CONSTRAINT "lend_books_to_after_from" CHECK ("to" > "from"),
+  ADD CONSTRAINT "lend_books_book_copy_id_no_overlap" EXCLUDE USING gist (
+    "book_copy_id" WITH =,
+    tstzrange("from", "to") WITH &&
+  ) WHERE ("returned_at" IS NULL AND "deleted_at" IS NULL);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE "public"."lend_books"
+  DROP CONSTRAINT "lend_books_book_copy_id_no_overlap",
+  DROP CONSTRAINT "lend_books_to_after_from";
+CREATE UNIQUE INDEX "lend_books_active_book_copy_id_idx" ON "public"."lend_books" ("book_copy_id")
+  WHERE "returned_at" IS NULL AND "deleted_at" IS NULL;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE "public"."holds" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  "deleted_at" timestamptz,
+  "book_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "status" text NOT NULL DEFAULT 'waiting',
+  "book_copy_id" uuid,
+  "ready_at" timestamptz,
+  "pickup_deadline" timestamptz,
+  CONSTRAINT "holds_pkey" PRIMARY KEY ("id"),
+  FOREIGN KEY ("book_id") REFERENCES "public"."books"("id"),
+  FOREIGN KEY ("user_id") REFERENCES "public"."users"("id"),
+  FOREIGN KEY ("book_copy_id") REFERENCES "public"."book_copies"("id")
+) WITH (oids = false);
+
+-- queue of a book is read in order of creation
+CREATE INDEX "holds_book_id_created_at_idx" ON "public"."holds" ("book_id", "created_at")
+  WHERE "status" IN ('waiting', 'ready');
+
+-- a user has at most one open hold per book
+CREATE UNIQUE INDEX "holds_open_book_id_user_id_idx" ON "public"."holds" ("book_id", "user_id")
+  WHERE "status" IN ('waiting', 'ready') AND "deleted_at" IS NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP TABLE "public"."holds";
@@ -0,0 +1,28 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE "public"."ledger_entries" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  "deleted_at" timestamptz,
+  "user_id" uuid NOT NULL,
+  "lend_book_id" uuid,
+  "kind" text NOT NULL,
+  "amount" bigint NOT NULL,
+  "note" text,
+  CONSTRAINT "ledger_entries_pkey" PRIMARY KEY ("id"),
+  FOREIGN KEY ("user_id") REFERENCES "public"."users"("id"),
+  FOREIGN KEY ("lend_book_id") REFERENCES "public"."lend_books"("id")
+) WITH (oids = false);
+
+-- balance and ledger of a user are read in order of creation
+CREATE INDEX "ledger_entries_user_id_created_at_idx" ON "public"."ledger_entries" ("user_id", "created_at");
+
+-- fines charged for a lend book are summed on each accrual
+CREATE INDEX "ledger_entries_lend_book_id_idx" ON "public"."ledger_entries" ("lend_book_id")
+  WHERE "lend_book_id" IS NOT NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP TABLE "public"."ledger_entries";
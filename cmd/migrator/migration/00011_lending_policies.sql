@@ -0,0 +1,34 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE "public"."lending_policies" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  "deleted_at" timestamptz,
+  "name" text NOT NULL,
+  "category_id" uuid,
+  "tier" text NOT NULL DEFAULT '',
+  "loan_period_days" integer NOT NULL,
+  "max_active_loans" integer NOT NULL,
+  "max_renewals" integer NOT NULL DEFAULT 0,
+  "fine_per_day" bigint NOT NULL DEFAULT 0,
+  CONSTRAINT "lending_policies_pkey" PRIMARY KEY ("id"),
+  FOREIGN KEY ("category_id") REFERENCES "public"."categories"("id")
+) WITH (oids = false);
+
+-- a category and a tier have at most one policy, an empty one matches all
+CREATE UNIQUE INDEX "lending_policies_category_id_tier_idx" ON "public"."lending_policies"
+  (COALESCE("category_id", '00000000-0000-0000-0000-000000000000'), "tier")
+  WHERE "deleted_at" IS NULL;
+
+ALTER TABLE "public"."users" ADD COLUMN "tier" text NOT NULL DEFAULT 'standard';
+
+ALTER TABLE "public"."lend_books" ADD COLUMN "lending_policy_id" uuid
+  REFERENCES "public"."lending_policies"("id");
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE "public"."lend_books" DROP COLUMN "lending_policy_id";
+ALTER TABLE "public"."users" DROP COLUMN "tier";
+DROP TABLE "public"."lending_policies";
@@ -0,0 +1,31 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE "public"."tags" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  "deleted_at" timestamptz,
+  "name" text NOT NULL,
+  CONSTRAINT "tags_pkey" PRIMARY KEY ("id"),
+  -- names are normalized by service, lower case is enforced here too
+  CONSTRAINT "tags_name_key" UNIQUE ("name"),
+  CONSTRAINT "tags_name_check" CHECK ("name" = lower(btrim("name")) AND "name" <> '')
+) WITH (oids = false);
+
+CREATE TABLE "public"."book_tags" (
+  "book_id" uuid NOT NULL,
+  "tag_id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  CONSTRAINT "book_tags_pkey" PRIMARY KEY ("book_id", "tag_id"),
+  FOREIGN KEY ("book_id") REFERENCES "public"."books"("id"),
+  FOREIGN KEY ("tag_id") REFERENCES "public"."tags"("id")
+) WITH (oids = false);
+
+-- books of a tag are found when books are searched by tag
+CREATE INDEX "book_tags_tag_id_idx" ON "public"."book_tags" ("tag_id");
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP TABLE "public"."book_tags";
+DROP TABLE "public"."tags";
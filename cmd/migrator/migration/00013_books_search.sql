@@ -0,0 +1,18 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- weighted so a match in name ranks above author and description,
+-- generated columns need PostgreSQL 12
+ALTER TABLE "public"."books" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('english', coalesce("name", '')), 'A') ||
+  setweight(to_tsvector('english', coalesce("author", '')), 'B') ||
+  setweight(to_tsvector('english', coalesce("description", '')), 'C')
+) STORED;
+
+CREATE INDEX "books_search_vector_idx" ON "public"."books" USING GIN ("search_vector");
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX "public"."books_search_vector_idx";
+ALTER TABLE "public"."books" DROP COLUMN "search_vector";
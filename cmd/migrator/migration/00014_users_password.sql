@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- existing users have no password and can not login until one is set
+ALTER TABLE "public"."users"
+  ADD COLUMN "password_hash" text NOT NULL DEFAULT '';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE "public"."users" DROP COLUMN "password_hash";
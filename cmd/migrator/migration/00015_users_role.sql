@@ -0,0 +1,12 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- existing users are members, staff is promoted by an admin
+ALTER TABLE "public"."users"
+  ADD COLUMN "role" text NOT NULL DEFAULT 'member',
+  ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('admin', 'librarian', 'member'));
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE "public"."users" DROP COLUMN "role";
@@ -0,0 +1,24 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE "public"."api_keys" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz DEFAULT now(),
+  "deleted_at" timestamptz,
+  "name" text NOT NULL,
+  "prefix" text NOT NULL,
+  "key_hash" text NOT NULL,
+  "scopes" text[] NOT NULL DEFAULT '{}',
+  "created_by" uuid,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  CONSTRAINT "api_keys_pkey" PRIMARY KEY ("id"),
+  -- keys are looked up by hash on every request which uses them
+  CONSTRAINT "api_keys_key_hash_key" UNIQUE ("key_hash"),
+  FOREIGN KEY ("created_by") REFERENCES "public"."users"("id")
+) WITH (oids = false);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP TABLE "public"."api_keys";
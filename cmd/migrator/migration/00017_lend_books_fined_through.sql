@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+ALTER TABLE "public"."lend_books" ADD COLUMN "fined_through" timestamptz;
+
+-- fines are accrued only for lend books which are not settled yet
+CREATE INDEX "lend_books_unsettled_idx" ON "public"."lend_books" ("user_id", "to")
+  WHERE "returned_at" IS NULL OR "fined_through" IS NULL OR "fined_through" < "returned_at";
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX "public"."lend_books_unsettled_idx";
+ALTER TABLE "public"."lend_books" DROP COLUMN "fined_through";
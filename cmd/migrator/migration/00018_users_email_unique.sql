@@ -0,0 +1,23 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- email of a user who is not the oldest one with the same email is cleared,
+-- login took the oldest one so the others could not log in anyway. They keep
+-- their lend books and fines until staff give them a new email.
+UPDATE "public"."users" SET "email" = NULL
+WHERE "id" IN (
+  SELECT "id" FROM (
+    SELECT "id", row_number() OVER (PARTITION BY lower("email") ORDER BY "created_at", "id") AS "n"
+    FROM "public"."users"
+    WHERE "deleted_at" IS NULL AND "email" <> ''
+  ) "duplicates"
+  WHERE "n" > 1
+);
+
+CREATE UNIQUE INDEX "users_lower_email_idx" ON "public"."users" (lower("email"))
+  WHERE "deleted_at" IS NULL AND "email" <> '';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX "public"."users_lower_email_idx";
@@ -1,25 +1,42 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
 	"github.com/joho/godotenv"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/phungvandat/example-go/config"
 	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
 	"github.com/phungvandat/example-go/endpoints"
+	"github.com/phungvandat/example-go/health"
 	serviceHttp "github.com/phungvandat/example-go/http"
 	"github.com/phungvandat/example-go/service"
+	apiKeySvc "github.com/phungvandat/example-go/service/api_key"
+	authSvc "github.com/phungvandat/example-go/service/auth"
 	bookSvc "github.com/phungvandat/example-go/service/book"
+	bookCopySvc "github.com/phungvandat/example-go/service/book_copy"
 	categorySvc "github.com/phungvandat/example-go/service/category"
+	fineSvc "github.com/phungvandat/example-go/service/fine"
+	holdSvc "github.com/phungvandat/example-go/service/hold"
 	lendBookSvc "github.com/phungvandat/example-go/service/lend_book"
+	lendingPolicySvc "github.com/phungvandat/example-go/service/lending_policy"
+	tagSvc "github.com/phungvandat/example-go/service/tag"
 	userSvc "github.com/phungvandat/example-go/service/user"
+	"github.com/phungvandat/example-go/trace"
 )
 
 func main() {
@@ -31,9 +48,6 @@ func main() {
 		}
 	}
 
-	// setup addrr
-	httpAddr := ":" + os.Getenv("PORT")
-
 	// setup log
 	var logger log.Logger
 	{
@@ -42,60 +56,302 @@ func main() {
 		logger = log.With(logger, "caller", log.DefaultCaller)
 	}
 
+	// setup config, flags take precedence over env and file
+	flags := flag.NewFlagSet("server", flag.ExitOnError)
+	loader := config.NewLoader(flags, "")
+	flags.Parse(os.Args[1:])
+	cfg, err := loader.Load()
+	if err != nil {
+		logger.Log("error", err)
+		os.Exit(1)
+	}
+	if loader.PrintRequested() {
+		cfg.Print(os.Stdout)
+		return
+	}
+	if err := cfg.ValidateServer(); err != nil {
+		logger.Log("error", err)
+		os.Exit(1)
+	}
+
+	// setup addrr, metrics are served apart so the API does not expose them
+	httpAddr := ":" + cfg.Port
+	metricsAddr := ":" + cfg.MetricsPort
+
 	// setup locale
-	{
-		loc, err := time.LoadLocation("Asia/Bangkok")
-		if err != nil {
-			logger.Log("error", err)
-			os.Exit(1)
-		}
-		time.Local = loc
+	time.Local = cfg.Location()
+
+	// setup tracing, spans are recorded only when an exporter is chosen
+	switch cfg.TraceExporter {
+	case "stdout":
+		trace.SetExporter(trace.NewWriterExporter(os.Stdout, "example-go"))
+	case "collector":
+		e := trace.NewCollectorExporter(cfg.TraceCollectorURL, "example-go", func(err error) {
+			logger.Log("trace", "export", "error", err)
+		})
+		defer e.Close()
+		trace.SetExporter(e)
 	}
 
+	// setup metrics of services, exposed at /metrics of metricsAddr
+	fieldKeys := []string{"service", "method"}
+	var (
+		requestCount = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "example_go",
+			Subsystem: "service",
+			Name:      "requests_total",
+			Help:      "Number of calls of service methods.",
+		}, fieldKeys)
+		errorCount = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "example_go",
+			Subsystem: "service",
+			Name:      "errors_total",
+			Help:      "Number of calls of service methods which failed.",
+		}, fieldKeys)
+		requestLatency = kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "example_go",
+			Subsystem: "service",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of calls of service methods in seconds.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, fieldKeys)
+		lendBookCount = kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: "example_go",
+			Name:      "lend_books",
+			Help:      "Number of lend books which are not returned, by status.",
+		}, []string{"status"})
+	)
+
+	// setup database, postgres which is still starting is waited for
+	pgDB, closeDB, err := pg.New(cfg.DataSource(), pg.Options{
+		MaxOpenConns:     cfg.DBMaxOpenConns,
+		MaxIdleConns:     cfg.DBMaxIdleConns,
+		ConnMaxLifetime:  cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime:  cfg.DBConnMaxIdleTime,
+		StatementTimeout: cfg.DBStatementTimeout,
+		ConnectTimeout:   cfg.DBConnectTimeout,
+	})
+	if err != nil {
+		logger.Log("error", err)
+		os.Exit(1)
+	}
+	defer closeDB()
+	trace.RegisterCallbacks()
+
 	// setup service
 	var (
-		pgDB, closeDB = pg.New(os.Getenv("PG_DATASOURCE"))
-		s             = service.Service{
+		s = service.Service{
+			AuthService: service.Compose(
+				authSvc.NewPGService(pgDB, authSvc.NewSigner(cfg.JWTSecret)),
+				authSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				authSvc.ValidationMiddleware(),
+			).(authSvc.Service),
+			APIKeyService: service.Compose(
+				apiKeySvc.NewPGService(pgDB),
+				apiKeySvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				apiKeySvc.AuthorizationMiddleware(),
+				apiKeySvc.ValidationMiddleware(),
+			).(apiKeySvc.Service),
 			UserService: service.Compose(
 				userSvc.NewPGService(pgDB),
+				userSvc.LoggingMiddleware(logger),
+				userSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				userSvc.AuthorizationMiddleware(),
 				userSvc.ValidationMiddleware(),
 			).(userSvc.Service),
 			CategoryService: service.Compose(
 				categorySvc.NewPGService(pgDB),
+				categorySvc.LoggingMiddleware(logger),
+				categorySvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				categorySvc.AuthorizationMiddleware(),
 				categorySvc.ValidationMiddleware(),
 			).(categorySvc.Service),
 			BookService: service.Compose(
 				bookSvc.NewPGService(pgDB),
+				bookSvc.LoggingMiddleware(logger),
+				bookSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				bookSvc.AuthorizationMiddleware(),
 				bookSvc.ValidationMiddleware(),
 			).(bookSvc.Service),
+			BookCopyService: service.Compose(
+				bookCopySvc.NewPGService(pgDB),
+				bookCopySvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				bookCopySvc.AuthorizationMiddleware(),
+				bookCopySvc.ValidationMiddleware(),
+			).(bookCopySvc.Service),
 			LendBookService: service.Compose(
-				lendBookSvc.NewPGService(pgDB),
+				lendBookSvc.NewPGService(pgDB, cfg.MaxOutstandingBalance),
+				lendBookSvc.LoggingMiddleware(logger),
+				lendBookSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				lendBookSvc.AuthorizationMiddleware(),
 				lendBookSvc.ValidationMiddleware(),
 			).(lendBookSvc.Service),
+			HoldService: service.Compose(
+				holdSvc.NewPGService(pgDB),
+				holdSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				holdSvc.AuthorizationMiddleware(),
+				holdSvc.ValidationMiddleware(),
+			).(holdSvc.Service),
+			FineService: service.Compose(
+				fineSvc.NewPGService(pgDB),
+				fineSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				fineSvc.AuthorizationMiddleware(),
+				fineSvc.ValidationMiddleware(),
+			).(fineSvc.Service),
+			LendingPolicyService: service.Compose(
+				lendingPolicySvc.NewPGService(pgDB),
+				lendingPolicySvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				lendingPolicySvc.AuthorizationMiddleware(),
+				lendingPolicySvc.ValidationMiddleware(),
+			).(lendingPolicySvc.Service),
+			TagService: service.Compose(
+				tagSvc.NewPGService(pgDB),
+				tagSvc.InstrumentingMiddleware(requestCount, errorCount, requestLatency),
+				tagSvc.AuthorizationMiddleware(),
+				tagSvc.ValidationMiddleware(),
+			).(tagSvc.Service),
 		}
 	)
-	defer closeDB()
+
+	// setup readiness, the schema must be at the latest migration shipped
+	// with server
+	var readiness *health.Checker
+	{
+		version, err := health.LatestMigration(cfg.MigrationDir)
+		if err != nil {
+			logger.Log("error", err)
+			os.Exit(1)
+		}
+		readiness = health.NewChecker(cfg.ReadinessTimeout).
+			Add("database", health.PingDB(pgDB.DB())).
+			Add("migration", health.MigrationVersion(pgDB.DB(), version))
+	}
 
 	var h http.Handler
 	{
 		h = serviceHttp.NewHTTPHandler(
 			endpoints.MakeServerEndpoints(s),
+			s.APIKeyService,
+			readiness,
 			logger,
-			os.Getenv("ENV") == "local",
+			cfg.CORSOrigins,
+			cfg.RequestTimeout,
 		)
 	}
 
-	errs := make(chan error)
-	go func() {
-		c := make(chan os.Signal)
-		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
-		errs <- fmt.Errorf("%s", <-c)
-	}()
+	// jobs are run by server itself, so they pass authorization of services
+	jobCtx := authSvc.NewSystemContext(context.Background())
+	stopJobs := make(chan struct{})
+	var jobs sync.WaitGroup
+
+	// roll expired holds to the next ones in queue
+	runEvery(time.Minute, stopJobs, &jobs, func() {
+		if err := s.HoldService.Expire(jobCtx); err != nil {
+			logger.Log("service", "hold", "error", err)
+		}
+	})
+
+	// charge fines of overdue lend books
+	runEvery(time.Hour, stopJobs, &jobs, func() {
+		if err := s.FineService.Accrue(jobCtx, nil); err != nil {
+			logger.Log("service", "fine", "error", err)
+		}
+	})
+
+	// count lend books by status for metrics, list total is the count
+	runEvery(time.Minute, stopJobs, &jobs, func() {
+		for _, status := range []domain.LendBookStatus{domain.LendBookStatusActive, domain.LendBookStatusOverdue} {
+			q := domain.ListQuery{Filters: map[string][]string{"status": {string(status)}}, Limit: 1}
+			_, meta, err := s.LendBookService.FindAll(jobCtx, &q)
+			if err != nil {
+				logger.Log("service", "lend_book", "error", err)
+				continue
+			}
+			lendBookCount.With("status", string(status)).Set(float64(meta.Total))
+		}
+	})
 
+	srv := &http.Server{
+		Addr:              httpAddr,
+		Handler:           h,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsSrv := &http.Server{
+		Addr:              metricsAddr,
+		Handler:           metricsMux,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	errs := make(chan error, 2)
 	go func() {
 		logger.Log("transport", "HTTP", "addr", httpAddr)
-		errs <- http.ListenAndServe(httpAddr, h)
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			errs <- err
+		}
 	}()
+	go func() {
+		logger.Log("transport", "metrics", "addr", metricsAddr)
+		if err := metricsSrv.ListenAndServe(); err != http.ErrServerClosed {
+			errs <- err
+		}
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 
-	logger.Log("exit", <-errs)
+	select {
+	case err := <-errs:
+		logger.Log("transport", "HTTP", "error", err)
+	case v := <-sig:
+		// readiness fails for drain period so traffic moves to other
+		// instances before listener is closed, a second signal skips it
+		logger.Log("signal", v, "drain", cfg.ShutdownDrainPeriod)
+		readiness.Drain()
+		select {
+		case <-time.After(cfg.ShutdownDrainPeriod):
+		case <-sig:
+		}
+	}
+
+	// requests in flight and running jobs finish before DB is closed
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Log("transport", "HTTP", "error", err)
+	}
+	if err := metricsSrv.Shutdown(ctx); err != nil {
+		logger.Log("transport", "metrics", "error", err)
+	}
+	close(stopJobs)
+	jobs.Wait()
+
+	logger.Log("exit", "shutdown")
+}
+
+// runEvery run f every d until stop is closed, wg is done once f which is
+// running returns
+func runEvery(d time.Duration, stop <-chan struct{}, wg *sync.WaitGroup, f func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(d)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				f()
+			}
+		}
+	}()
 }
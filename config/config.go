@@ -0,0 +1,358 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DBTypePostgres is the only type of database which is supported
+const DBTypePostgres = "postgres"
+
+// Config contain configuration of server and migrator
+type Config struct {
+	Env      string
+	Timezone string
+
+	Port                string
+	MetricsPort         string
+	CORSOrigins         []string
+	ReadHeaderTimeout   time.Duration
+	ReadTimeout         time.Duration
+	WriteTimeout        time.Duration
+	IdleTimeout         time.Duration
+	ShutdownDrainPeriod time.Duration
+	ShutdownTimeout     time.Duration
+	ReadinessTimeout    time.Duration
+	RequestTimeout      time.Duration
+
+	JWTSecret string
+
+	DBType             string
+	DBDataSource       string
+	DBUserName         string
+	DBPassword         string
+	DBName             string
+	DBSSLModeOption    string
+	DBHostname         string
+	DBPort             string
+	DBMaxOpenConns     int
+	DBMaxIdleConns     int
+	DBConnMaxLifetime  time.Duration
+	DBConnMaxIdleTime  time.Duration
+	DBStatementTimeout time.Duration
+	DBConnectTimeout   time.Duration
+	MigrationDir       string
+
+	TraceExporter     string
+	TraceCollectorURL string
+
+	MaxOutstandingBalance int64
+}
+
+// option is a setting of Config, key is its name in env and files, flag
+// name is key in lower case with dashes
+type option struct {
+	key    string
+	target interface{}
+	def    string
+	usage  string
+	secret bool
+}
+
+func (o option) flag() string {
+	return strings.Replace(strings.ToLower(o.key), "_", "-", -1)
+}
+
+// set parse s into target of o
+func (o option) set(s string) error {
+	switch t := o.target.(type) {
+	case *string:
+		*t = s
+	case *int:
+		if s == "" {
+			*t = 0
+			return nil
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*t = v
+	case *int64:
+		if s == "" {
+			*t = 0
+			return nil
+		}
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		*t = v
+	case *time.Duration:
+		if s == "" {
+			*t = 0
+			return nil
+		}
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*t = v
+	case *[]string:
+		*t = nil
+		for _, v := range strings.Split(s, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				*t = append(*t, v)
+			}
+		}
+	}
+	return nil
+}
+
+// String format target of o as it is given in env
+func (o option) String() string {
+	switch t := o.target.(type) {
+	case *string:
+		return *t
+	case *int:
+		return strconv.Itoa(*t)
+	case *int64:
+		return strconv.FormatInt(*t, 10)
+	case *time.Duration:
+		return t.String()
+	case *[]string:
+		return strings.Join(*t, ",")
+	}
+	return ""
+}
+
+// options list every setting of c with its default
+func (c *Config) options() []option {
+	return []option{
+		{"ENV", &c.Env, "", "environment, local loads .env and allows any CORS origin", false},
+		{"TIMEZONE", &c.Timezone, "Asia/Bangkok", "IANA time zone which dates are in", false},
+
+		{"PORT", &c.Port, "3000", "port which HTTP server listens on", false},
+		{"METRICS_PORT", &c.MetricsPort, "3001", "port which /metrics for Prometheus is served on, it should not be public", false},
+		{"CORS_ORIGINS", &c.CORSOrigins, "", "comma separated origins allowed by CORS, none disables CORS", false},
+		{"HTTP_READ_HEADER_TIMEOUT", &c.ReadHeaderTimeout, "5s", "time to read headers of a request", false},
+		{"HTTP_READ_TIMEOUT", &c.ReadTimeout, "15s", "time to read a whole request", false},
+		{"HTTP_WRITE_TIMEOUT", &c.WriteTimeout, "30s", "time to write a response", false},
+		{"HTTP_IDLE_TIMEOUT", &c.IdleTimeout, "60s", "time a keep-alive connection waits for the next request", false},
+		{"SHUTDOWN_DRAIN_PERIOD", &c.ShutdownDrainPeriod, "5s", "time readiness fails before server stops listening", false},
+		{"SHUTDOWN_TIMEOUT", &c.ShutdownTimeout, "30s", "time requests in flight are given to finish on shutdown", false},
+		{"REQUEST_TIMEOUT", &c.RequestTimeout, "20s", "time a request is given before it is canceled with 504, 0 is unlimited", false},
+		{"READINESS_TIMEOUT", &c.ReadinessTimeout, "2s", "time each readiness check is given", false},
+
+		{"JWT_SECRET", &c.JWTSecret, "", "secret which access tokens are signed by", true},
+
+		{"DB_TYPE", &c.DBType, DBTypePostgres, "type of database", false},
+		{"PG_DATASOURCE", &c.DBDataSource, "", "postgres connection string, it takes precedence over DB_* parts", true},
+		{"DB_USERNAME", &c.DBUserName, "", "user of database", false},
+		{"DB_PASSWORD", &c.DBPassword, "", "password of database", true},
+		{"DB_NAME", &c.DBName, "", "name of database", false},
+		{"DB_SSLMODE_OPTION", &c.DBSSLModeOption, "", "enable to require SSL to database", false},
+		{"DB_HOSTNAME", &c.DBHostname, "localhost", "host of database", false},
+		{"DB_PORT", &c.DBPort, "5432", "port of database", false},
+		{"DB_MAX_OPEN_CONNS", &c.DBMaxOpenConns, "25", "maximum open connections to database, 0 is unlimited", false},
+		{"DB_MAX_IDLE_CONNS", &c.DBMaxIdleConns, "5", "maximum idle connections to database", false},
+		{"DB_CONN_MAX_LIFETIME", &c.DBConnMaxLifetime, "30m", "time a connection to database is reused, 0 is forever", false},
+		{"DB_CONN_MAX_IDLE_TIME", &c.DBConnMaxIdleTime, "5m", "time a connection to database stays idle, 0 is forever", false},
+		{"DB_STATEMENT_TIMEOUT", &c.DBStatementTimeout, "30s", "time a statement may run before database cancels it, 0 is unlimited", false},
+		{"DB_CONNECT_TIMEOUT", &c.DBConnectTimeout, "30s", "time connecting to database is retried on startup", false},
+		{"MIGRATION_DIR", &c.MigrationDir, "cmd/migrator/migration", "directory with migration files", false},
+
+		{"TRACE_EXPORTER", &c.TraceExporter, "", "where spans are exported, stdout or collector, none disables tracing", false},
+		{"TRACE_COLLECTOR_URL", &c.TraceCollectorURL, "http://localhost:9411/api/v2/spans", "Zipkin v2 API of collector", false},
+
+		{"MAX_OUTSTANDING_BALANCE", &c.MaxOutstandingBalance, "1000", "greatest balance of fines of user who can lend books", false},
+	}
+}
+
+// DataSource return connection string of database, PG_DATASOURCE is used
+// when it is set, otherwise it is made of DB_* parts
+func (c *Config) DataSource() string {
+	if c.DBDataSource != "" {
+		return c.DBDataSource
+	}
+
+	sslmode := "disable"
+	if c.DBSSLModeOption == "enable" {
+		sslmode = "require"
+	}
+	return fmt.Sprintf("user=%s dbname=%s sslmode=%s password=%s host=%s port=%s",
+		c.DBUserName,
+		c.DBName,
+		sslmode,
+		c.DBPassword,
+		c.DBHostname,
+		c.DBPort,
+	)
+}
+
+// Location return location of Timezone, Validate ensures it is valid
+func (c *Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// validateDB check settings of database which every binary connects to
+func (c *Config) validateDB() error {
+	if c.DBType != DBTypePostgres {
+		return fmt.Errorf("config: unsupported DB_TYPE %q", c.DBType)
+	}
+	return nil
+}
+
+// ValidateMigrator check settings which migrator uses are usable, settings
+// of server are not checked
+func (c *Config) ValidateMigrator() error {
+	if err := c.validateDB(); err != nil {
+		return err
+	}
+	if c.MigrationDir == "" {
+		return fmt.Errorf("config: MIGRATION_DIR is required")
+	}
+	return nil
+}
+
+// ValidateServer check settings which server uses are usable
+func (c *Config) ValidateServer() error {
+	if err := c.validateDB(); err != nil {
+		return err
+	}
+	// tokens signed by an empty secret could be forged by anyone
+	if c.JWTSecret == "" {
+		return fmt.Errorf("config: JWT_SECRET is required")
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("config: invalid TIMEZONE: %v", err)
+	}
+	if p, err := strconv.Atoi(c.Port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("config: invalid PORT %q", c.Port)
+	}
+	if p, err := strconv.Atoi(c.MetricsPort); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("config: invalid METRICS_PORT %q", c.MetricsPort)
+	}
+	if c.MetricsPort == c.Port {
+		return fmt.Errorf("config: METRICS_PORT must differ from PORT")
+	}
+	if c.MaxOutstandingBalance < 0 {
+		return fmt.Errorf("config: MAX_OUTSTANDING_BALANCE must not be negative")
+	}
+	if c.DBMaxOpenConns < 0 || c.DBMaxIdleConns < 0 {
+		return fmt.Errorf("config: DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative")
+	}
+	for _, o := range c.options() {
+		if d, ok := o.target.(*time.Duration); ok && *d < 0 {
+			return fmt.Errorf("config: %s must not be negative", o.key)
+		}
+	}
+	// 504 of a request which times out must be written before connection is
+	// closed by write timeout
+	if c.WriteTimeout > 0 && c.RequestTimeout >= c.WriteTimeout {
+		return fmt.Errorf("config: REQUEST_TIMEOUT must be shorter than HTTP_WRITE_TIMEOUT")
+	}
+	switch c.TraceExporter {
+	case "", "stdout", "collector":
+	default:
+		return fmt.Errorf("config: unsupported TRACE_EXPORTER %q", c.TraceExporter)
+	}
+	return nil
+}
+
+// Print write every setting of c as KEY=value lines, secrets are redacted
+func (c *Config) Print(w io.Writer) error {
+	for _, o := range c.options() {
+		v := o.String()
+		if o.secret && v != "" {
+			v = "[REDACTED]"
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", o.key, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Loader load Config from, by precedence, flags, env, a file and defaults
+type Loader struct {
+	fs    *flag.FlagSet
+	file  *string
+	print *bool
+}
+
+// NewLoader add flags of every setting to fs, and flags -config for file
+// and -print-config. file is read when it exists, a file given by flag
+// must exist
+func NewLoader(fs *flag.FlagSet, file string) *Loader {
+	l := &Loader{
+		fs:    fs,
+		file:  fs.String("config", file, "file of configuration, ie. config.yaml"),
+		print: fs.Bool("print-config", false, "print configuration with secrets redacted and exit"),
+	}
+	for _, o := range (&Config{}).options() {
+		fs.String(o.flag(), o.def, o.usage)
+	}
+	return l
+}
+
+// PrintRequested report -print-config is given
+func (l *Loader) PrintRequested() bool {
+	return *l.print
+}
+
+// Load read Config once fs is parsed, it is validated by binary which uses
+// it so it can be printed before, see ValidateServer and ValidateMigrator
+func (l *Loader) Load() (*Config, error) {
+	v := viper.New()
+	v.AutomaticEnv()
+
+	cfg := &Config{}
+	opts := cfg.options()
+	for _, o := range opts {
+		v.SetDefault(o.key, o.def)
+	}
+
+	explicit := false
+	flags := map[string]string{}
+	l.fs.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			explicit = true
+		}
+		flags[f.Name] = f.Value.String()
+	})
+
+	if *l.file != "" {
+		v.SetConfigFile(*l.file)
+		if err := v.ReadInConfig(); err != nil && (explicit || !os.IsNotExist(err)) {
+			return nil, fmt.Errorf("config: can't read %s: %v", *l.file, err)
+		}
+	}
+
+	for _, o := range opts {
+		if s, ok := flags[o.flag()]; ok {
+			v.Set(o.key, s)
+		}
+		if err := o.set(v.GetString(o.key)); err != nil {
+			return nil, fmt.Errorf("config: invalid %s: %v", o.key, err)
+		}
+	}
+
+	// any origin may call API which runs on local
+	if cfg.Env == "local" && len(cfg.CORSOrigins) == 0 {
+		cfg.CORSOrigins = []string{"*"}
+	}
+
+	return cfg, nil
+}
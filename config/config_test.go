@@ -0,0 +1,210 @@
+// +build unit
+
+package config
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoader_Load(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	err = ioutil.WriteFile(file, []byte("PORT: 4000\nTIMEZONE: UTC\nDB_NAME: file\nSHUTDOWN_TIMEOUT: 10s\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("TIMEZONE", "Asia/Tokyo")
+	os.Setenv("DB_NAME", "env")
+	defer os.Unsetenv("TIMEZONE")
+	defer os.Unsetenv("DB_NAME")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l := NewLoader(fs, file)
+	if err := fs.Parse([]string{"-db-name", "flag", "-cors-origins", "a.com, b.com"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := l.Load()
+	if err != nil {
+		t.Fatalf("Loader.Load() error = %v", err)
+	}
+
+	if cfg.Port != "4000" {
+		t.Errorf("Loader.Load() PORT of file = %v, want 4000", cfg.Port)
+	}
+	if cfg.Timezone != "Asia/Tokyo" {
+		t.Errorf("Loader.Load() TIMEZONE of env = %v, want Asia/Tokyo", cfg.Timezone)
+	}
+	if cfg.DBName != "flag" {
+		t.Errorf("Loader.Load() DB_NAME of flag = %v, want flag", cfg.DBName)
+	}
+	if cfg.ShutdownTimeout != 10*time.Second {
+		t.Errorf("Loader.Load() SHUTDOWN_TIMEOUT = %v, want 10s", cfg.ShutdownTimeout)
+	}
+	if cfg.ReadTimeout != 15*time.Second || cfg.DBMaxOpenConns != 25 {
+		t.Errorf("Loader.Load() defaults = %v, %v", cfg.ReadTimeout, cfg.DBMaxOpenConns)
+	}
+	if strings.Join(cfg.CORSOrigins, "|") != "a.com|b.com" {
+		t.Errorf("Loader.Load() CORS_ORIGINS = %v", cfg.CORSOrigins)
+	}
+}
+
+func TestLoader_Load_file(t *testing.T) {
+	// default file may be missing
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l := NewLoader(fs, "not_exist.yaml")
+	fs.Parse(nil)
+	if _, err := l.Load(); err != nil {
+		t.Errorf("Loader.Load() of missing default file error = %v", err)
+	}
+
+	// file given by flag must exist
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	l = NewLoader(fs, "")
+	fs.Parse([]string{"-config", "not_exist.yaml"})
+	if _, err := l.Load(); err == nil {
+		t.Errorf("Loader.Load() of missing file by flag error = nil")
+	}
+}
+
+func TestLoader_Load_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"invalid duration", []string{"-shutdown-timeout", "soon"}},
+		{"invalid number", []string{"-db-max-open-conns", "many"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			l := NewLoader(fs, "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := l.Load(); err == nil {
+				t.Errorf("Loader.Load() error = nil, want an error")
+			}
+		})
+	}
+}
+
+// load return Config of args, given on top of a valid JWT_SECRET
+func load(t *testing.T, args ...string) *Config {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l := NewLoader(fs, "")
+	if err := fs.Parse(append([]string{"-jwt-secret", "secret"}, args...)); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := l.Load()
+	if err != nil {
+		t.Fatalf("Loader.Load() error = %v", err)
+	}
+	return cfg
+}
+
+func TestConfig_ValidateServer(t *testing.T) {
+	if err := load(t).ValidateServer(); err != nil {
+		t.Errorf("Config.ValidateServer() of defaults error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing jwt secret", []string{"-jwt-secret", ""}},
+		{"invalid timezone", []string{"-timezone", "Nowhere/Nothing"}},
+		{"invalid port", []string{"-port", "http"}},
+		{"metrics port same as port", []string{"-metrics-port", "3000"}},
+		{"negative duration", []string{"-http-read-timeout", "-1s"}},
+		{"negative pool size", []string{"-db-max-idle-conns", "-1"}},
+		{"unsupported db type", []string{"-db-type", "mysql"}},
+		{"unsupported trace exporter", []string{"-trace-exporter", "kafka"}},
+		{"request timeout longer than write timeout", []string{"-request-timeout", "1m", "-http-write-timeout", "30s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := load(t, tt.args...).ValidateServer(); err == nil {
+				t.Errorf("Config.ValidateServer() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateMigrator(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name: "settings of server are not checked",
+			args: []string{"-jwt-secret", "", "-port", "http", "-request-timeout", "1m"},
+		},
+		{
+			name:    "unsupported db type",
+			args:    []string{"-db-type", "mysql"},
+			wantErr: true,
+		},
+		{
+			name:    "missing migration dir",
+			args:    []string{"-migration-dir", ""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := load(t, tt.args...).ValidateMigrator()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.ValidateMigrator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Print(t *testing.T) {
+	cfg := &Config{
+		Port:         "3000",
+		JWTSecret:    "secret",
+		DBDataSource: "user=postgres password=example",
+		DBName:       "go-ex",
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.Print(&buf); err != nil {
+		t.Fatalf("Config.Print() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"PORT=3000\n", "DB_NAME=go-ex\n", "JWT_SECRET=[REDACTED]\n", "PG_DATASOURCE=[REDACTED]\n", "DB_PASSWORD=\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Config.Print() = %v, want it contains %q", out, want)
+		}
+	}
+	if strings.Contains(out, "secret") || strings.Contains(out, "example") {
+		t.Errorf("Config.Print() = %v, it leaks a secret", out)
+	}
+}
+
+func TestConfig_DataSource(t *testing.T) {
+	cfg := &Config{DBUserName: "postgres", DBName: "go-ex", DBPassword: "example", DBHostname: "localhost", DBPort: "5432", DBSSLModeOption: "enable"}
+	want := "user=postgres dbname=go-ex sslmode=require password=example host=localhost port=5432"
+	if got := cfg.DataSource(); got != want {
+		t.Errorf("Config.DataSource() = %v, want %v", got, want)
+	}
+
+	cfg.DBDataSource = "postgres://localhost/go-ex"
+	if got := cfg.DataSource(); got != cfg.DBDataSource {
+		t.Errorf("Config.DataSource() = %v, want %v", got, cfg.DBDataSource)
+	}
+}
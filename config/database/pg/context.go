@@ -0,0 +1,52 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+)
+
+// contextDB run statements of db bound to ctx, gorm v1 has no context so it
+// is given to gorm as the connection
+type contextDB struct {
+	ctx context.Context
+	db  *sql.DB
+}
+
+func (c *contextDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(c.ctx, query)
+}
+
+func (c *contextDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(c.ctx, query, args...)
+}
+
+// Begin start a transaction which is rolled back once ctx is done
+func (c *contextDB) Begin() (*sql.Tx, error) {
+	return c.db.BeginTx(c.ctx, nil)
+}
+
+// WithContext return db whose statements and transactions are canceled once
+// ctx is done. The bound DB is opened by gorm on contextDB, which is neither
+// pinged nor closed, it shares connections of db. db which is a transaction
+// or is already bound is returned as it is
+func WithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	sqlDB := db.DB()
+	if sqlDB == nil {
+		return db
+	}
+	bound, err := gorm.Open("postgres", &contextDB{ctx: ctx, db: sqlDB})
+	if err != nil {
+		return db
+	}
+	return bound
+}
@@ -0,0 +1,65 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+)
+
+// nopDriver open connections which run nothing, so a DB can be opened
+// without postgres
+type nopDriver struct{}
+
+func (nopDriver) Open(string) (driver.Conn, error) { return nopConn{}, nil }
+
+type nopConn struct{}
+
+func (nopConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("nop: prepare") }
+func (nopConn) Close() error                        { return nil }
+func (nopConn) Begin() (driver.Tx, error)           { return nil, errors.New("nop: begin") }
+
+func init() {
+	sql.Register("pg-test-nop", nopDriver{})
+}
+
+func TestWithContext(t *testing.T) {
+	sqlDB, err := sql.Open("pg-test-nop", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open("postgres", sqlDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// statements of a canceled context fail before a connection is taken
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bound := WithContext(ctx, db)
+
+	if err := bound.Exec("SELECT 1").Error; err != context.Canceled {
+		t.Errorf("Exec() error = %v, want %v", err, context.Canceled)
+	}
+	var n int
+	if err := bound.Raw("SELECT 1").Row().Scan(&n); err != context.Canceled {
+		t.Errorf("Row() error = %v, want %v", err, context.Canceled)
+	}
+	if err := bound.Begin().Error; err != context.Canceled {
+		t.Errorf("Begin() error = %v, want %v", err, context.Canceled)
+	}
+
+	// db itself is not bound
+	if db.DB() != sqlDB {
+		t.Errorf("WithContext() changed connection of db")
+	}
+
+	// a bound db is not bound again
+	if got := WithContext(context.Background(), bound); got != bound {
+		t.Errorf("WithContext() of bound db = %v, want it as it is", got)
+	}
+}
@@ -1,23 +1,97 @@
 package pg
 
 import (
+	"fmt"
 	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
 
-// New create new postgres database connection
+// Options tune pool of connections and connecting to postgres, zero values
+// keep defaults of database/sql
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout is set on every connection, statements running longer
+	// are canceled by postgres
+	StatementTimeout time.Duration
+	// ConnectTimeout is deadline of retries when postgres is not reachable,
+	// zero tries once
+	ConnectTimeout time.Duration
+}
+
+// Backoff between attempts to connect
+const (
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 5 * time.Second
+)
+
+// New create new postgres database connection, it is retried with
+// exponential backoff until opts.ConnectTimeout passes
 // it return postgres connection and cleanup postgres connection
-func New(ds string) (*gorm.DB, func()) {
-	db, err := gorm.Open("postgres", ds)
+func New(ds string, opts Options) (*gorm.DB, func(), error) {
+	ds, err := withStatementTimeout(ds, opts.StatementTimeout)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
+	}
+
+	var (
+		db       *gorm.DB
+		deadline = time.Now().Add(opts.ConnectTimeout)
+		backoff  = minBackoff
+	)
+	for attempt := 1; ; attempt++ {
+		db, err = gorm.Open("postgres", ds)
+		if err == nil {
+			break
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, nil, fmt.Errorf("failed to connect to postgres after %d attempts: %v", attempt, err)
+		}
+		log.Println("Failed to connect to postgres, retrying in", backoff, "by error", err)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
 	}
 
+	db.DB().SetMaxOpenConns(opts.MaxOpenConns)
+	db.DB().SetMaxIdleConns(opts.MaxIdleConns)
+	db.DB().SetConnMaxLifetime(opts.ConnMaxLifetime)
+	db.DB().SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+
 	return db, func() {
 		err := db.Close()
 		if err != nil {
 			log.Println("Failed to close DB by error", err)
 		}
+	}, nil
+}
+
+// withStatementTimeout add statement_timeout to ds, which postgres sets on
+// every connection made by it. ds is either a URL or key=value pairs
+func withStatementTimeout(ds string, d time.Duration) (string, error) {
+	if d <= 0 {
+		return ds, nil
+	}
+	ms := strconv.FormatInt(int64(d/time.Millisecond), 10)
+
+	if strings.HasPrefix(ds, "postgres://") || strings.HasPrefix(ds, "postgresql://") {
+		u, err := url.Parse(ds)
+		if err != nil {
+			return "", fmt.Errorf("invalid postgres data source: %v", err)
+		}
+		q := u.Query()
+		q.Set("statement_timeout", ms)
+		u.RawQuery = q.Encode()
+		return u.String(), nil
 	}
+
+	return strings.TrimSpace(ds + " statement_timeout=" + ms), nil
 }
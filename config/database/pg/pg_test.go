@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"testing"
+	"time"
+
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+)
+
+func Test_withStatementTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		ds   string
+		d    time.Duration
+		want string
+	}{
+		{
+			name: "no timeout",
+			ds:   "user=postgres dbname=go-ex",
+			want: "user=postgres dbname=go-ex",
+		},
+		{
+			name: "key value pairs",
+			ds:   "user=postgres dbname=go-ex",
+			d:    30 * time.Second,
+			want: "user=postgres dbname=go-ex statement_timeout=30000",
+		},
+		{
+			name: "url",
+			ds:   "postgres://postgres@localhost/go-ex?sslmode=disable",
+			d:    1500 * time.Millisecond,
+			want: "postgres://postgres@localhost/go-ex?sslmode=disable&statement_timeout=1500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withStatementTimeout(tt.ds, tt.d)
+			if err != nil {
+				t.Fatalf("withStatementTimeout() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("withStatementTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_unreachable(t *testing.T) {
+	start := time.Now()
+	db, closeDB, err := New("host=127.0.0.1 port=1 user=postgres sslmode=disable connect_timeout=1", Options{
+		ConnectTimeout: 500 * time.Millisecond,
+	})
+	if err == nil {
+		closeDB()
+		t.Fatalf("New() error = nil, want an error")
+	}
+	if db != nil {
+		t.Errorf("New() db = %v, want nil", db)
+	}
+	if took := time.Since(start); took > 5*time.Second {
+		t.Errorf("New() took %v, want it gives up after ConnectTimeout", took)
+	}
+}
@@ -0,0 +1,26 @@
+package pg
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Transaction run f in a transaction of db, it is committed when f returns
+// nil and rolled back otherwise. f joins db which is already a transaction,
+// so statements of several services can be done in one transaction
+func Transaction(db *gorm.DB, f func(tx *gorm.DB) error) error {
+	if _, ok := db.CommonDB().(*sql.Tx); ok {
+		return f(db)
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
@@ -62,10 +62,34 @@ func CreateTestDatabase(t *testing.T) (*gorm.DB, string, func()) {
 
 // MigrateTables migrate db with tables base by domain model
 func MigrateTables(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		domain.User{},
 		domain.Category{},
 		domain.Book{},
+		domain.BookCopy{},
 		domain.LendBook{},
+		domain.Hold{},
+		domain.LedgerEntry{},
+		domain.LendingPolicy{},
+		domain.Tag{},
+		domain.BookTag{},
+		domain.APIKey{},
 	).Error
+	if err != nil {
+		return err
+	}
+
+	// search vector of books is generated by database, see migration 00013
+	err = db.Exec(`ALTER TABLE books ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(author, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'C')
+	) STORED`).Error
+	if err != nil {
+		return err
+	}
+
+	// email of user is unique regardless of case, see migration 00018
+	return db.Exec(`CREATE UNIQUE INDEX users_lower_email_idx ON users (lower(email))
+		WHERE deleted_at IS NULL AND email <> ''`).Error
 }
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Actions which scopes of API key permit on a resource
+const (
+	ScopeActionRead  = "read"
+	ScopeActionWrite = "write"
+)
+
+// APIKeyResources is resources which scopes of API key apply to, a resource
+// is the first segment of paths of the API, ie. "books" of /books/{book_id}
+var APIKeyResources = []string{
+	"users",
+	"categories",
+	"books",
+	"book_copies",
+	"lend_books",
+	"holds",
+	"policies",
+	"tags",
+}
+
+// Scope return scope which permits action on resource, ie. "books:write"
+func Scope(resource, action string) string {
+	return resource + ":" + action
+}
+
+// IsValidScope check scope is an action on one of APIKeyResources
+func IsValidScope(scope string) bool {
+	parts := strings.Split(scope, ":")
+	if len(parts) != 2 || (parts[1] != ScopeActionRead && parts[1] != ScopeActionWrite) {
+		return false
+	}
+	for _, resource := range APIKeyResources {
+		if parts[0] == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey describe a key which machine clients use in place of login
+type APIKey struct {
+	Model
+	Name       string      `json:"name"`
+	Prefix     string      `json:"prefix"`
+	KeyHash    string      `json:"-"`
+	Scopes     StringArray `sql:"type:text[]" json:"scopes"`
+	CreatedBy  UUID        `json:"created_by"`
+	LastUsedAt *time.Time  `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time  `json:"revoked_at,omitempty"`
+
+	// Key is given in plain text once when it is created, only its hash is stored
+	Key string `sql:"-" json:"key,omitempty"`
+}
+
+// HasScope check key permits scope, a write scope permits read of its resource too
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+		if strings.HasSuffix(scope, ":"+ScopeActionRead) &&
+			s == strings.TrimSuffix(scope, ScopeActionRead)+ScopeActionWrite {
+			return true
+		}
+	}
+	return false
+}
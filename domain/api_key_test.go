@@ -0,0 +1,46 @@
+// +build unit
+
+package domain
+
+import "testing"
+
+func TestAPIKey_HasScope(t *testing.T) {
+	key := APIKey{Scopes: []string{"books:write", "lend_books:read"}}
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: "books:write", want: true},
+		{scope: "books:read", want: true},
+		{scope: "lend_books:read", want: true},
+		{scope: "lend_books:write", want: false},
+		{scope: "users:read", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			if got := key.HasScope(tt.scope); got != tt.want {
+				t.Errorf("APIKey.HasScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidScope(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: "books:write", want: true},
+		{scope: "lend_books:read", want: true},
+		{scope: "books:delete", want: false},
+		{scope: "api_keys:write", want: false},
+		{scope: "books", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			if got := IsValidScope(tt.scope); got != tt.want {
+				t.Errorf("IsValidScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+// Credentials describe what a user logs in with
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Token describe tokens issued to a user who logs in, access token is sent
+// as bearer token and refresh token is exchanged for new tokens
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
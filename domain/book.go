@@ -7,4 +7,19 @@ type Book struct {
 	CategoryID  UUID   `json:"category_id"`
 	Author      string `json:"author"`
 	Description string `json:"description"`
+
+	// counts of book copies, computed when book is loaded
+	TotalCopies     int `sql:"-" json:"total_copies"`
+	AvailableCopies int `sql:"-" json:"available_copies"`
+
+	// names of tags of book, loaded with book
+	Tags []string `sql:"-" json:"tags"`
+}
+
+// BookSearchResult describe a book found by full-text search, Snippet
+// is text of book where terms of search are marked
+type BookSearchResult struct {
+	Book
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+)
+
+// BookCopyCondition describe physical condition of a book copy
+type BookCopyCondition string
+
+// List of book copy condition
+const (
+	BookCopyConditionNew     BookCopyCondition = "new"
+	BookCopyConditionGood    BookCopyCondition = "good"
+	BookCopyConditionFair    BookCopyCondition = "fair"
+	BookCopyConditionPoor    BookCopyCondition = "poor"
+	BookCopyConditionDamaged BookCopyCondition = "damaged"
+)
+
+// IsValid check condition is one of known book copy condition
+func (c BookCopyCondition) IsValid() bool {
+	switch c {
+	case BookCopyConditionNew, BookCopyConditionGood, BookCopyConditionFair,
+		BookCopyConditionPoor, BookCopyConditionDamaged:
+		return true
+	}
+	return false
+}
+
+// BookCopy describe a physical copy of book in system
+type BookCopy struct {
+	Model
+	BookID        UUID              `json:"book_id"`
+	Barcode       string            `json:"barcode"`
+	Condition     BookCopyCondition `json:"condition"`
+	ShelfLocation string            `json:"shelf_location"`
+	AcquiredAt    time.Time         `json:"acquired_at"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+)
+
+// HoldStatus describe status of a hold in queue of a book
+type HoldStatus string
+
+// List of hold status
+const (
+	HoldStatusWaiting   HoldStatus = "waiting"
+	HoldStatusReady     HoldStatus = "ready"
+	HoldStatusFulfilled HoldStatus = "fulfilled"
+	HoldStatusExpired   HoldStatus = "expired"
+	HoldStatusCancelled HoldStatus = "cancelled"
+)
+
+// Hold describe a user waiting in queue for a book which is lended
+type Hold struct {
+	Model
+	BookID         UUID       `json:"book_id"`
+	UserID         UUID       `json:"user_id"`
+	Status         HoldStatus `json:"status"`
+	BookCopyID     UUID       `json:"book_copy_id"`
+	ReadyAt        *time.Time `json:"ready_at,omitempty"`
+	PickupDeadline *time.Time `json:"pickup_deadline,omitempty"`
+
+	// position in queue of book, computed for waiting holds
+	Position int `sql:"-" json:"position,omitempty"`
+}
+
+// IsOpen check hold is still in queue or waiting for pickup
+func (h *Hold) IsOpen() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
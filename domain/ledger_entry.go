@@ -0,0 +1,27 @@
+package domain
+
+// LedgerEntryKind describe kind of an entry in ledger of user
+type LedgerEntryKind string
+
+// List of ledger entry kind
+const (
+	LedgerEntryKindFine    LedgerEntryKind = "fine"
+	LedgerEntryKindPayment LedgerEntryKind = "payment"
+)
+
+// LedgerEntry describe a charge or a payment in account of user,
+// Amount is in minor currency unit, charges are positive and payments are negative
+type LedgerEntry struct {
+	Model
+	UserID     UUID            `json:"user_id"`
+	LendBookID UUID            `json:"lend_book_id"`
+	Kind       LedgerEntryKind `json:"kind"`
+	Amount     int64           `json:"amount"`
+	Note       string          `json:"note"`
+}
+
+// Balance describe outstanding amount of user, it is sum of ledger entries
+type Balance struct {
+	UserID UUID  `json:"user_id"`
+	Amount int64 `json:"amount"`
+}
@@ -4,11 +4,52 @@ import (
 	"time"
 )
 
-//Lend book describe lend book in system
+// LendBookStatus describe status of a lend book in its lifecycle
+type LendBookStatus string
+
+// List of lend book status
+const (
+	LendBookStatusActive   LendBookStatus = "active"
+	LendBookStatusReturned LendBookStatus = "returned"
+	LendBookStatusOverdue  LendBookStatus = "overdue"
+	LendBookStatusLost     LendBookStatus = "lost"
+)
+
+// Lend book describe lend book in system
 type LendBook struct {
 	Model
-	BookID UUID      `json:"book_id"`
-	UserID UUID      `json:"user_id"`
-	From   time.Time `json:"from"`
-	To     time.Time `json:"time"`
+	BookID     UUID           `json:"book_id"`
+	BookCopyID UUID           `json:"book_copy_id"`
+	UserID     UUID           `json:"user_id"`
+	From       time.Time      `json:"from"`
+	To         time.Time      `json:"to"`
+	ReturnedAt *time.Time     `json:"returned_at,omitempty"`
+	Status     LendBookStatus `json:"status"`
+	RenewCount int            `json:"renew_count"`
+
+	// policy resolved when book is lended, it governs renewals and fines
+	LendingPolicyID UUID `json:"lending_policy_id"`
+	// fine is charged up to FinedThrough, a returned lend book whose fine
+	// is charged up to ReturnedAt is settled
+	FinedThrough *time.Time `json:"-"`
+}
+
+// IsReturned check book of this lend book was given back
+func (l *LendBook) IsReturned() bool {
+	return l.ReturnedAt != nil
+}
+
+// CurrentStatus return status of lend book at time now,
+// an active lend book which is past To is overdue
+func (l *LendBook) CurrentStatus(now time.Time) LendBookStatus {
+	if l.IsReturned() {
+		return LendBookStatusReturned
+	}
+	if l.Status == LendBookStatusLost {
+		return LendBookStatusLost
+	}
+	if !l.To.IsZero() && now.After(l.To) {
+		return LendBookStatusOverdue
+	}
+	return LendBookStatusActive
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+)
+
+// MembershipTier describe membership tier of user
+type MembershipTier string
+
+// List of membership tier
+const (
+	MembershipTierStandard MembershipTier = "standard"
+	MembershipTierPremium  MembershipTier = "premium"
+	MembershipTierStaff    MembershipTier = "staff"
+)
+
+// IsValid check tier is one of membership tiers
+func (t MembershipTier) IsValid() bool {
+	switch t {
+	case MembershipTierStandard, MembershipTierPremium, MembershipTierStaff:
+		return true
+	}
+	return false
+}
+
+// LendingPolicy describe rules of lending books, it applies to books of CategoryID
+// and users of Tier, an empty CategoryID or Tier matches any one
+type LendingPolicy struct {
+	Model
+	Name           string         `json:"name"`
+	CategoryID     UUID           `json:"category_id"`
+	Tier           MembershipTier `json:"tier"`
+	LoanPeriodDays int            `json:"loan_period_days"`
+	MaxActiveLoans int            `json:"max_active_loans"`
+	MaxRenewals    int            `json:"max_renewals"`
+	FinePerDay     int64          `json:"fine_per_day"`
+}
+
+// DefaultLendingPolicy return policy applied when no policy matches a lend book
+func DefaultLendingPolicy() *LendingPolicy {
+	return &LendingPolicy{
+		Name:           "default",
+		LoanPeriodDays: 14,
+		MaxActiveLoans: 5,
+		MaxRenewals:    2,
+		FinePerDay:     100,
+	}
+}
+
+// LoanPeriod return length of a loan, also length of a renewal
+func (p *LendingPolicy) LoanPeriod() time.Duration {
+	return time.Duration(p.LoanPeriodDays) * 24 * time.Hour
+}
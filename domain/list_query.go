@@ -0,0 +1,30 @@
+package domain
+
+// SortField describe a field which a list is sorted by
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery describe filters, sorting and page of a list, the page
+// starts after Cursor which is given by previous page
+type ListQuery struct {
+	Filters map[string][]string
+	Sort    []SortField
+	Limit   int
+	Cursor  string
+}
+
+// Filter return value of filter name, empty when it is not given
+func (q *ListQuery) Filter(name string) string {
+	if q == nil || len(q.Filters[name]) == 0 {
+		return ""
+	}
+	return q.Filters[name][0]
+}
+
+// ListMeta describe a page of a list, NextCursor is empty on the last page
+type ListMeta struct {
+	NextCursor string `json:"next_cursor"`
+	Total      int    `json:"total"`
+}
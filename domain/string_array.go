@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errInvalidStringArray is returned when a value is not an array literal of postgres
+var errInvalidStringArray = errors.New("invalid string array format")
+
+// StringArray implement for postgres convert one-dimensional text[] without NULL
+type StringArray []string
+
+// Value format a as array literal, each element is quoted
+func (a StringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, s := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		for _, r := range s {
+			if r == '"' || r == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan parse array literal, ie. {books:read,"a b"}
+func (a *StringArray) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("can not scan %T into StringArray", src)
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return errInvalidStringArray
+	}
+	s = s[1 : len(s)-1]
+
+	res := StringArray{}
+	for len(s) > 0 {
+		var elem strings.Builder
+		if s[0] == '"' {
+			// quoted element, a backslash escapes the next character
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+					if i == len(s) {
+						break
+					}
+				}
+				elem.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return errInvalidStringArray
+			}
+			s = s[i+1:]
+		} else {
+			i := strings.IndexByte(s, ',')
+			if i < 0 {
+				i = len(s)
+			}
+			if s[:i] == "NULL" {
+				return errors.New("NULL element is not supported by StringArray")
+			}
+			elem.WriteString(s[:i])
+			s = s[i:]
+		}
+		res = append(res, elem.String())
+
+		if len(s) > 0 {
+			if s[0] != ',' {
+				return errInvalidStringArray
+			}
+			s = s[1:]
+		}
+	}
+	*a = res
+	return nil
+}
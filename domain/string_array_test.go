@@ -0,0 +1,87 @@
+// +build unit
+
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStringArray_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    StringArray
+		wantErr bool
+	}{
+		{
+			name: "nil",
+			src:  nil,
+			want: nil,
+		},
+		{
+			name: "empty array",
+			src:  []byte("{}"),
+			want: StringArray{},
+		},
+		{
+			name: "plain elements",
+			src:  []byte("{books:read,lend_books:write}"),
+			want: StringArray{"books:read", "lend_books:write"},
+		},
+		{
+			name: "quoted elements",
+			src:  `{"a b","say \"hi\"","back\\slash",""}`,
+			want: StringArray{"a b", `say "hi"`, `back\slash`, ""},
+		},
+		{
+			name:    "not an array",
+			src:     []byte("books:read"),
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			src:     []byte(`{"books:read}`),
+			wantErr: true,
+		},
+		{
+			name:    "NULL element",
+			src:     []byte("{books:read,NULL}"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got StringArray
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StringArray.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StringArray.Scan() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringArray_Value(t *testing.T) {
+	values := []StringArray{
+		{},
+		{"books:read", "lend_books:write"},
+		{"a b", `say "hi"`, `back\slash`, "", "{a,b}"},
+	}
+	for _, want := range values {
+		v, err := want.Value()
+		if err != nil {
+			t.Fatalf("StringArray.Value() error = %v", err)
+		}
+		var got StringArray
+		if err := got.Scan(v); err != nil {
+			t.Fatalf("StringArray.Scan() of %v error = %v", v, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("StringArray.Scan() of Value() = %#v, want %#v", got, want)
+		}
+	}
+}
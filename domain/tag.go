@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Tag describe tag which is given to books
+type Tag struct {
+	Model
+	Name string `json:"name"`
+
+	// number of books having the tag, computed when tags are listed
+	BookCount int `sql:"-" json:"book_count"`
+}
+
+// BookTag describe a tag given to a book
+type BookTag struct {
+	BookID    UUID      `json:"book_id"`
+	TagID     UUID      `json:"tag_id"`
+	CreatedAt time.Time `sql:"default:now()" json:"created_at"`
+}
+
+// NormalizeTagNames trim and case fold names of tags,
+// a name given more than once is kept once in its first place
+func NormalizeTagNames(names []string) []string {
+	res := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		res = append(res, name)
+	}
+	return res
+}
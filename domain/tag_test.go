@@ -0,0 +1,39 @@
+// +build unit
+
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTagNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{
+			name:  "trimmed and case folded",
+			names: []string{"  Science ", "FICTION"},
+			want:  []string{"science", "fiction"},
+		},
+		{
+			name:  "deduplicated after normalized",
+			names: []string{"Science", "science ", "fiction", "SCIENCE"},
+			want:  []string{"science", "fiction"},
+		},
+		{
+			name:  "no name",
+			names: []string{},
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTagNames(tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeTagNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,38 @@
 package domain
 
+// Role describe what user is permitted to do
+type Role string
+
+// List of role
+const (
+	RoleAdmin     Role = "admin"
+	RoleLibrarian Role = "librarian"
+	RoleMember    Role = "member"
+)
+
+// IsValid check role is one of roles
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleLibrarian, RoleMember:
+		return true
+	}
+	return false
+}
+
+// IsStaff check role is one of staff who manage library
+func (r Role) IsStaff() bool {
+	return r == RoleAdmin || r == RoleLibrarian
+}
+
 // User describe user in systenm
 type User struct {
 	Model
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name  string         `json:"name"`
+	Email string         `json:"email"`
+	Tier  MembershipTier `json:"tier"`
+	Role  Role           `json:"role"`
+
+	// Password is given in plain text when it is set, only its hash is stored
+	Password     string `sql:"-" json:"-"`
+	PasswordHash string `json:"-"`
 }
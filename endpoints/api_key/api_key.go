@@ -0,0 +1,103 @@
+package api_key
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+)
+
+// CreateData data for CreateAPIKey
+type CreateData struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateRequest request struct for CreateAPIKey
+type CreateRequest struct {
+	APIKey CreateData `json:"api_key"`
+}
+
+// CreateResponse response struct for CreateAPIKey, key is in plain text only here
+type CreateResponse struct {
+	APIKey domain.APIKey `json:"api_key"`
+}
+
+// StatusCode customstatus code for success create APIKey
+func (CreateResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+// MakeCreateEndpoint make endpoint for create a APIKey
+func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req    = request.(CreateRequest)
+			apiKey = &domain.APIKey{
+				Name:   req.APIKey.Name,
+				Scopes: req.APIKey.Scopes,
+			}
+		)
+
+		err := s.APIKeyService.Create(ctx, apiKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return CreateResponse{APIKey: *apiKey}, nil
+	}
+}
+
+// FindAllRequest request struct for FindAll APIKey
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
+
+// FindAllResponse request struct for find all APIKey
+type FindAllResponse struct {
+	APIKeys []domain.APIKey `json:"api_keys"`
+	domain.ListMeta
+}
+
+// MakeFindAllEndpoint make endpoint for find all APIKey
+func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(FindAllRequest)
+		apiKeys, meta, err := s.APIKeyService.FindAll(ctx, &req.Query)
+		if err != nil {
+			return nil, err
+		}
+		return FindAllResponse{APIKeys: apiKeys, ListMeta: *meta}, nil
+	}
+}
+
+// RevokeRequest request struct for revoke a APIKey
+type RevokeRequest struct {
+	APIKeyID domain.UUID
+}
+
+// RevokeResponse response struct for revoke a APIKey
+type RevokeResponse struct {
+	APIKey domain.APIKey `json:"api_key"`
+}
+
+// MakeRevokeEndpoint make endpoint for revoke a APIKey
+func MakeRevokeEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			apiKeyFind = domain.APIKey{}
+			req        = request.(RevokeRequest)
+		)
+		apiKeyFind.ID = req.APIKeyID
+
+		res, err := s.APIKeyService.Revoke(ctx, &apiKeyFind)
+		if err != nil {
+			return nil, err
+		}
+
+		return RevokeResponse{APIKey: *res}, nil
+	}
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+	authSvc "github.com/phungvandat/example-go/service/auth"
+)
+
+// LoginData data for Login
+type LoginData struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginRequest request struct for Login
+type LoginRequest struct {
+	Credentials LoginData `json:"credentials"`
+}
+
+// TokenResponse response struct for tokens which are issued
+type TokenResponse struct {
+	Token domain.Token `json:"token"`
+}
+
+// MakeLoginEndpoint make endpoint for login by credentials
+func MakeLoginEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(LoginRequest)
+		token, err := s.AuthService.Login(ctx, &domain.Credentials{
+			Email:    req.Credentials.Email,
+			Password: req.Credentials.Password,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return TokenResponse{Token: *token}, nil
+	}
+}
+
+// RefreshRequest request struct for Refresh
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// MakeRefreshEndpoint make endpoint for exchange a refresh token to new tokens
+func MakeRefreshEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RefreshRequest)
+		token, err := s.AuthService.Refresh(ctx, req.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+		return TokenResponse{Token: *token}, nil
+	}
+}
+
+// MakeAuthenticateMiddleware make middleware which verifies bearer token of
+// request and places its principal in context, see auth.FromContext. A
+// request which is authenticated by API key has its principal already
+func MakeAuthenticateMiddleware(s service.Service) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if _, ok := authSvc.FromContext(ctx); ok {
+				return next(ctx, request)
+			}
+			principal, err := s.AuthService.Verify(ctx, authSvc.TokenFromContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return next(authSvc.NewContext(ctx, principal), request)
+		}
+	}
+}
+
+// MakeOptionalAuthenticateMiddleware make middleware like
+// MakeAuthenticateMiddleware for endpoints which anonymous callers may use
+// too, a request without bearer token goes on without principal, while a
+// given token must be valid
+func MakeOptionalAuthenticateMiddleware(s service.Service) endpoint.Middleware {
+	authenticate := MakeAuthenticateMiddleware(s)
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		authenticated := authenticate(next)
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if _, ok := authSvc.FromContext(ctx); !ok && authSvc.TokenFromContext(ctx) == "" {
+				return next(ctx, request)
+			}
+			return authenticated(ctx, request)
+		}
+	}
+}
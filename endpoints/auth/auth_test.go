@@ -0,0 +1,87 @@
+// +build unit
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/endpoints/user"
+	"github.com/phungvandat/example-go/service"
+	authSvc "github.com/phungvandat/example-go/service/auth"
+	userSvc "github.com/phungvandat/example-go/service/user"
+)
+
+func TestMakeOptionalAuthenticateMiddleware(t *testing.T) {
+	adminID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	memberID := domain.MustGetUUIDFromString("415179ad-8067-4138-9b0d-41e0c68d4376")
+	mock := service.Service{
+		AuthService: &authSvc.ServiceMock{
+			VerifyFunc: func(_ context.Context, accessToken string) (*authSvc.Principal, error) {
+				switch accessToken {
+				case "admin":
+					return &authSvc.Principal{UserID: adminID, Role: domain.RoleAdmin}, nil
+				case "member":
+					return &authSvc.Principal{UserID: memberID, Role: domain.RoleMember}, nil
+				}
+				return nil, authSvc.ErrInvalidToken
+			},
+		},
+		UserService: userSvc.AuthorizationMiddleware()(&userSvc.ServiceMock{
+			CreateFunc: func(_ context.Context, p *domain.User) error {
+				return nil
+			},
+		}),
+	}
+	createUser := MakeOptionalAuthenticateMiddleware(mock)(user.MakeCreateEndpoint(mock))
+
+	tests := []struct {
+		name    string
+		token   string
+		role    domain.Role
+		wantErr error
+	}{
+		{
+			name:  "admin creates librarian",
+			token: "admin",
+			role:  domain.RoleLibrarian,
+		},
+		{
+			name: "anonymous signs up as member",
+			role: domain.RoleMember,
+		},
+		{
+			name:    "anonymous can not sign up as librarian",
+			role:    domain.RoleLibrarian,
+			wantErr: authSvc.ErrForbidden,
+		},
+		{
+			name:    "member can not create user",
+			token:   "member",
+			role:    domain.RoleMember,
+			wantErr: authSvc.ErrForbidden,
+		},
+		{
+			name:    "invalid token is not taken as anonymous",
+			token:   "invalid",
+			role:    domain.RoleMember,
+			wantErr: authSvc.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authSvc.NewTokenContext(context.Background(), tt.token)
+			req := user.CreateRequest{User: user.CreateData{
+				Name:     "Aliquam feugiat tellus ut neque.",
+				Email:    "example@gmail.com",
+				Password: "s3cret-password",
+				Role:     tt.role,
+			}}
+			_, err := createUser(ctx, req)
+			if err != tt.wantErr {
+				t.Errorf("CreateUser endpoint error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
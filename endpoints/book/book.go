@@ -8,6 +8,7 @@ import (
 
 	"github.com/phungvandat/example-go/domain"
 	"github.com/phungvandat/example-go/service"
+	bookSvc "github.com/phungvandat/example-go/service/book"
 )
 
 // CreateData data for CreateBook
@@ -55,6 +56,71 @@ func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
 	}
 }
 
+// CreateBatchData data for CreateBatchBook
+type CreateBatchData struct {
+	Mode  bookSvc.BatchMode `json:"mode"`
+	Books []CreateData      `json:"books"`
+}
+
+// CreateBatchRequest request struct for CreateBatchBook
+type CreateBatchRequest struct {
+	BookBatch CreateBatchData `json:"book_batch"`
+}
+
+// CreateBatchResponse response struct for CreateBatchBook, Errors
+// lists failed books when batch is created in best effort mode
+type CreateBatchResponse struct {
+	Books  []domain.Book            `json:"books"`
+	Errors []bookSvc.BatchItemError `json:"errors,omitempty"`
+}
+
+// StatusCode customstatus code for success create batch of Book
+func (r CreateBatchResponse) StatusCode() int {
+	if len(r.Errors) > 0 {
+		return http.StatusMultiStatus
+	}
+	return http.StatusCreated
+}
+
+// MakeCreateBatchEndpoint make endpoint for create a batch of Book
+func MakeCreateBatchEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req   = request.(CreateBatchRequest)
+			books = make([]*domain.Book, 0, len(req.BookBatch.Books))
+		)
+		for _, data := range req.BookBatch.Books {
+			books = append(books, &domain.Book{
+				Name:        data.Name,
+				CategoryID:  data.CategoryID,
+				Author:      data.Author,
+				Description: data.Description,
+			})
+		}
+
+		res := CreateBatchResponse{Books: []domain.Book{}}
+		err := s.BookService.CreateBatch(ctx, books, req.BookBatch.Mode)
+		batchErr, ok := err.(bookSvc.BatchError)
+		// in best effort mode the batch succeeds when some books are created
+		partial := ok && req.BookBatch.Mode == bookSvc.BatchModeBestEffort && len(batchErr.Items) < len(books)
+		if err != nil && !partial {
+			return nil, err
+		}
+
+		failed := map[int]bool{}
+		for _, item := range batchErr.Items {
+			failed[item.Index] = true
+		}
+		for i, book := range books {
+			if !failed[i] {
+				res.Books = append(res.Books, *book)
+			}
+		}
+		res.Errors = batchErr.Items
+		return res, nil
+	}
+}
+
 // FindRequest request struct for Find a Book
 type FindRequest struct {
 	BookID domain.UUID
@@ -81,22 +147,48 @@ func MakeFindEndPoint(s service.Service) endpoint.Endpoint {
 }
 
 // FindAllRequest request struct for FindAll Book
-type FindAllRequest struct{}
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
 
 // FindAllResponse request struct for find all Book
 type FindAllResponse struct {
 	Books []domain.Book `json:"books"`
+	domain.ListMeta
 }
 
 // MakeFindAllEndpoint make endpoint for find all Book
 func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		_ = request.(FindAllRequest)
-		books, err := s.BookService.FindAll(ctx)
+		req := request.(FindAllRequest)
+		books, meta, err := s.BookService.FindAll(ctx, &req.Query)
+		if err != nil {
+			return nil, err
+		}
+		return FindAllResponse{Books: books, ListMeta: *meta}, nil
+	}
+}
+
+// SearchRequest request struct for full-text search of Book
+type SearchRequest struct {
+	Query string
+	Limit int
+}
+
+// SearchResponse response struct for full-text search of Book
+type SearchResponse struct {
+	Books []domain.BookSearchResult `json:"books"`
+}
+
+// MakeSearchEndpoint make endpoint for full-text search of Book
+func MakeSearchEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SearchRequest)
+		books, err := s.BookService.Search(ctx, req.Query, req.Limit)
 		if err != nil {
 			return nil, err
 		}
-		return FindAllResponse{Books: books}, nil
+		return SearchResponse{Books: books}, nil
 	}
 }
 
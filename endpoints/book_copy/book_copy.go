@@ -0,0 +1,179 @@
+package book_copy
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+)
+
+// CreateData data for CreateBookCopy
+type CreateData struct {
+	BookID        domain.UUID              `json:"book_id"`
+	Barcode       string                   `json:"barcode"`
+	Condition     domain.BookCopyCondition `json:"condition"`
+	ShelfLocation string                   `json:"shelf_location"`
+	AcquiredAt    time.Time                `json:"acquired_at"`
+}
+
+// CreateRequest request struct for CreateBookCopy
+type CreateRequest struct {
+	BookCopy CreateData `json:"book_copy"`
+}
+
+// CreateResponse response struct for CreateBookCopy
+type CreateResponse struct {
+	BookCopy domain.BookCopy `json:"book_copy"`
+}
+
+// StatusCode customstatus code for success create BookCopy
+func (CreateResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+// MakeCreateEndpoint make endpoint for create a BookCopy
+func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req      = request.(CreateRequest)
+			bookCopy = &domain.BookCopy{
+				BookID:        req.BookCopy.BookID,
+				Barcode:       req.BookCopy.Barcode,
+				Condition:     req.BookCopy.Condition,
+				ShelfLocation: req.BookCopy.ShelfLocation,
+				AcquiredAt:    req.BookCopy.AcquiredAt,
+			}
+		)
+
+		err := s.BookCopyService.Create(ctx, bookCopy)
+		if err != nil {
+			return nil, err
+		}
+
+		return CreateResponse{BookCopy: *bookCopy}, nil
+	}
+}
+
+// FindRequest request struct for Find a BookCopy
+type FindRequest struct {
+	BookCopyID domain.UUID
+}
+
+// FindResponse response struct for Find a BookCopy
+type FindResponse struct {
+	BookCopy *domain.BookCopy `json:"book_copy"`
+}
+
+// MakeFindEndPoint make endpoint for find BookCopy
+func MakeFindEndPoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var bookCopyFind domain.BookCopy
+		req := request.(FindRequest)
+		bookCopyFind.ID = req.BookCopyID
+
+		bookCopy, err := s.BookCopyService.Find(ctx, &bookCopyFind)
+		if err != nil {
+			return nil, err
+		}
+		return FindResponse{BookCopy: bookCopy}, nil
+	}
+}
+
+// FindAllRequest request struct for FindAll BookCopy
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
+
+// FindAllResponse request struct for find all BookCopy
+type FindAllResponse struct {
+	BookCopies []domain.BookCopy `json:"book_copies"`
+	domain.ListMeta
+}
+
+// MakeFindAllEndpoint make endpoint for find all BookCopy
+func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(FindAllRequest)
+		bookCopies, meta, err := s.BookCopyService.FindAll(ctx, &req.Query)
+		if err != nil {
+			return nil, err
+		}
+		return FindAllResponse{BookCopies: bookCopies, ListMeta: *meta}, nil
+	}
+}
+
+// UpdateData data for Update
+type UpdateData struct {
+	ID            domain.UUID              `json:"-"`
+	BookID        domain.UUID              `json:"book_id"`
+	Barcode       string                   `json:"barcode"`
+	Condition     domain.BookCopyCondition `json:"condition"`
+	ShelfLocation string                   `json:"shelf_location"`
+	AcquiredAt    time.Time                `json:"acquired_at"`
+}
+
+// UpdateRequest request struct for update
+type UpdateRequest struct {
+	BookCopy UpdateData `json:"book_copy"`
+}
+
+// UpdateResponse response struct for Update
+type UpdateResponse struct {
+	BookCopy domain.BookCopy `json:"book_copy"`
+}
+
+// MakeUpdateEndpoint make endpoint for update a BookCopy
+func MakeUpdateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req      = request.(UpdateRequest)
+			bookCopy = domain.BookCopy{
+				Model:         domain.Model{ID: req.BookCopy.ID},
+				BookID:        req.BookCopy.BookID,
+				Barcode:       req.BookCopy.Barcode,
+				Condition:     req.BookCopy.Condition,
+				ShelfLocation: req.BookCopy.ShelfLocation,
+				AcquiredAt:    req.BookCopy.AcquiredAt,
+			}
+		)
+
+		res, err := s.BookCopyService.Update(ctx, &bookCopy)
+		if err != nil {
+			return nil, err
+		}
+
+		return UpdateResponse{BookCopy: *res}, nil
+	}
+}
+
+// DeleteRequest request struct for delete a BookCopy
+type DeleteRequest struct {
+	BookCopyID domain.UUID
+}
+
+// DeleteResponse response struct for delete a BookCopy
+type DeleteResponse struct {
+	Status string `json:"status"`
+}
+
+// MakeDeleteEndpoint make endpoint for delete a BookCopy
+func MakeDeleteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			bookCopyFind = domain.BookCopy{}
+			req          = request.(DeleteRequest)
+		)
+		bookCopyFind.ID = req.BookCopyID
+
+		err := s.BookCopyService.Delete(ctx, &bookCopyFind)
+		if err != nil {
+			return nil, err
+		}
+
+		return DeleteResponse{"success"}, nil
+	}
+}
@@ -75,22 +75,25 @@ func MakeFindEndPoint(s service.Service) endpoint.Endpoint {
 }
 
 // FindAllRequest request struct for FindAll Category
-type FindAllRequest struct{}
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
 
 // FindAllResponse request struct for find all Category
 type FindAllResponse struct {
 	Categories []domain.Category `json:"categories"`
+	domain.ListMeta
 }
 
 // MakeFindAllEndpoint make endpoint for find all Category
 func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		_ = request.(FindAllRequest)
-		categories, err := s.CategoryService.FindAll(ctx)
+		req := request.(FindAllRequest)
+		categories, meta, err := s.CategoryService.FindAll(ctx, &req.Query)
 		if err != nil {
 			return nil, err
 		}
-		return FindAllResponse{Categories: categories}, nil
+		return FindAllResponse{Categories: categories, ListMeta: *meta}, nil
 	}
 }
 
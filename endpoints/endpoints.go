@@ -1,17 +1,34 @@
 package endpoints
 
 import (
+	"reflect"
+
 	"github.com/go-kit/kit/endpoint"
 	"github.com/phungvandat/example-go/service"
+	"github.com/phungvandat/example-go/trace"
 
+	"github.com/phungvandat/example-go/endpoints/api_key"
+	"github.com/phungvandat/example-go/endpoints/auth"
 	"github.com/phungvandat/example-go/endpoints/book"
+	"github.com/phungvandat/example-go/endpoints/book_copy"
 	"github.com/phungvandat/example-go/endpoints/category"
+	"github.com/phungvandat/example-go/endpoints/fine"
+	"github.com/phungvandat/example-go/endpoints/hold"
 	"github.com/phungvandat/example-go/endpoints/lend_book"
+	"github.com/phungvandat/example-go/endpoints/lending_policy"
+	"github.com/phungvandat/example-go/endpoints/tag"
 	"github.com/phungvandat/example-go/endpoints/user"
 )
 
 // Endpoints .
 type Endpoints struct {
+	LoginAuth   endpoint.Endpoint
+	RefreshAuth endpoint.Endpoint
+
+	CreateAPIKey  endpoint.Endpoint
+	FindAllAPIKey endpoint.Endpoint
+	RevokeAPIKey  endpoint.Endpoint
+
 	FindUser    endpoint.Endpoint
 	FindAllUser endpoint.Endpoint
 	CreateUser  endpoint.Endpoint
@@ -24,44 +41,130 @@ type Endpoints struct {
 	UpdateCategory  endpoint.Endpoint
 	DeleteCategory  endpoint.Endpoint
 
-	FindBook    endpoint.Endpoint
-	FindAllBook endpoint.Endpoint
-	CreateBook  endpoint.Endpoint
-	UpdateBook  endpoint.Endpoint
-	DeleteBook  endpoint.Endpoint
-
-	FindLendBook    endpoint.Endpoint
-	FindAllLendBook endpoint.Endpoint
-	CreateLendBook  endpoint.Endpoint
-	UpdateLendBook  endpoint.Endpoint
-	DeleteLendBook  endpoint.Endpoint
+	FindBook        endpoint.Endpoint
+	FindAllBook     endpoint.Endpoint
+	SearchBook      endpoint.Endpoint
+	CreateBook      endpoint.Endpoint
+	CreateBatchBook endpoint.Endpoint
+	UpdateBook      endpoint.Endpoint
+	DeleteBook      endpoint.Endpoint
+
+	FindBookCopy    endpoint.Endpoint
+	FindAllBookCopy endpoint.Endpoint
+	CreateBookCopy  endpoint.Endpoint
+	UpdateBookCopy  endpoint.Endpoint
+	DeleteBookCopy  endpoint.Endpoint
+
+	FindLendBook        endpoint.Endpoint
+	FindAllLendBook     endpoint.Endpoint
+	CreateLendBook      endpoint.Endpoint
+	CreateBatchLendBook endpoint.Endpoint
+	UpdateLendBook      endpoint.Endpoint
+	DeleteLendBook      endpoint.Endpoint
+	ReturnLendBook      endpoint.Endpoint
+	RenewLendBook       endpoint.Endpoint
+
+	CreateHold        endpoint.Endpoint
+	FindAllHoldByUser endpoint.Endpoint
+	CancelHold        endpoint.Endpoint
+
+	BalanceUser endpoint.Endpoint
+	LedgerUser  endpoint.Endpoint
+	PayUser     endpoint.Endpoint
+
+	FindLendingPolicy    endpoint.Endpoint
+	FindAllLendingPolicy endpoint.Endpoint
+	CreateLendingPolicy  endpoint.Endpoint
+	UpdateLendingPolicy  endpoint.Endpoint
+	DeleteLendingPolicy  endpoint.Endpoint
+
+	FindAllTag        endpoint.Endpoint
+	AddTagToBook      endpoint.Endpoint
+	RemoveTagFromBook endpoint.Endpoint
 }
 
 // MakeServerEndpoints returns an Endpoints struct
 func MakeServerEndpoints(s service.Service) Endpoints {
-	return Endpoints{
-		FindUser:    user.MakeFindEndPoint(s),
-		FindAllUser: user.MakeFindAllEndpoint(s),
-		CreateUser:  user.MakeCreateEndpoint(s),
-		UpdateUser:  user.MakeUpdateEndpoint(s),
-		DeleteUser:  user.MakeDeleteEndpoint(s),
-
-		FindCategory:    category.MakeFindEndPoint(s),
-		FindAllCategory: category.MakeFindAllEndpoint(s),
-		CreateCategory:  category.MakeCreateEndpoint(s),
-		UpdateCategory:  category.MakeUpdateEndpoint(s),
-		DeleteCategory:  category.MakeDeleteEndpoint(s),
-
-		FindBook:    book.MakeFindEndPoint(s),
-		FindAllBook: book.MakeFindAllEndpoint(s),
-		CreateBook:  book.MakeCreateEndpoint(s),
-		UpdateBook:  book.MakeUpdateEndpoint(s),
-		DeleteBook:  book.MakeDeleteEndpoint(s),
-
-		FindLendBook:    lend_book.MakeFindEndPoint(s),
-		FindAllLendBook: lend_book.MakeFindAllEndpoint(s),
-		CreateLendBook:  lend_book.MakeCreateEndpoint(s),
-		UpdateLendBook:  lend_book.MakeUpdateEndpoint(s),
-		DeleteLendBook:  lend_book.MakeDeleteEndpoint(s),
+	// every endpoint requires an access token except the ones to get it
+	// and to sign up, staff who create users are authenticated by theirs
+	authenticate := auth.MakeAuthenticateMiddleware(s)
+	authenticateOptional := auth.MakeOptionalAuthenticateMiddleware(s)
+
+	e := Endpoints{
+		LoginAuth:   auth.MakeLoginEndpoint(s),
+		RefreshAuth: auth.MakeRefreshEndpoint(s),
+
+		CreateAPIKey:  authenticate(api_key.MakeCreateEndpoint(s)),
+		FindAllAPIKey: authenticate(api_key.MakeFindAllEndpoint(s)),
+		RevokeAPIKey:  authenticate(api_key.MakeRevokeEndpoint(s)),
+
+		FindUser:    authenticate(user.MakeFindEndPoint(s)),
+		FindAllUser: authenticate(user.MakeFindAllEndpoint(s)),
+		CreateUser:  authenticateOptional(user.MakeCreateEndpoint(s)),
+		UpdateUser:  authenticate(user.MakeUpdateEndpoint(s)),
+		DeleteUser:  authenticate(user.MakeDeleteEndpoint(s)),
+
+		FindCategory:    authenticate(category.MakeFindEndPoint(s)),
+		FindAllCategory: authenticate(category.MakeFindAllEndpoint(s)),
+		CreateCategory:  authenticate(category.MakeCreateEndpoint(s)),
+		UpdateCategory:  authenticate(category.MakeUpdateEndpoint(s)),
+		DeleteCategory:  authenticate(category.MakeDeleteEndpoint(s)),
+
+		FindBook:        authenticate(book.MakeFindEndPoint(s)),
+		FindAllBook:     authenticate(book.MakeFindAllEndpoint(s)),
+		SearchBook:      authenticate(book.MakeSearchEndpoint(s)),
+		CreateBook:      authenticate(book.MakeCreateEndpoint(s)),
+		CreateBatchBook: authenticate(book.MakeCreateBatchEndpoint(s)),
+		UpdateBook:      authenticate(book.MakeUpdateEndpoint(s)),
+		DeleteBook:      authenticate(book.MakeDeleteEndpoint(s)),
+
+		FindBookCopy:    authenticate(book_copy.MakeFindEndPoint(s)),
+		FindAllBookCopy: authenticate(book_copy.MakeFindAllEndpoint(s)),
+		CreateBookCopy:  authenticate(book_copy.MakeCreateEndpoint(s)),
+		UpdateBookCopy:  authenticate(book_copy.MakeUpdateEndpoint(s)),
+		DeleteBookCopy:  authenticate(book_copy.MakeDeleteEndpoint(s)),
+
+		FindLendBook:        authenticate(lend_book.MakeFindEndPoint(s)),
+		FindAllLendBook:     authenticate(lend_book.MakeFindAllEndpoint(s)),
+		CreateLendBook:      authenticate(lend_book.MakeCreateEndpoint(s)),
+		CreateBatchLendBook: authenticate(lend_book.MakeCreateBatchEndpoint(s)),
+		UpdateLendBook:      authenticate(lend_book.MakeUpdateEndpoint(s)),
+		DeleteLendBook:      authenticate(lend_book.MakeDeleteEndpoint(s)),
+		ReturnLendBook:      authenticate(lend_book.MakeReturnEndpoint(s)),
+		RenewLendBook:       authenticate(lend_book.MakeRenewEndpoint(s)),
+
+		CreateHold:        authenticate(hold.MakeCreateEndpoint(s)),
+		FindAllHoldByUser: authenticate(hold.MakeFindAllByUserEndpoint(s)),
+		CancelHold:        authenticate(hold.MakeCancelEndpoint(s)),
+
+		BalanceUser: authenticate(fine.MakeBalanceEndpoint(s)),
+		LedgerUser:  authenticate(fine.MakeLedgerEndpoint(s)),
+		PayUser:     authenticate(fine.MakePayEndpoint(s)),
+
+		FindLendingPolicy:    authenticate(lending_policy.MakeFindEndPoint(s)),
+		FindAllLendingPolicy: authenticate(lending_policy.MakeFindAllEndpoint(s)),
+		CreateLendingPolicy:  authenticate(lending_policy.MakeCreateEndpoint(s)),
+		UpdateLendingPolicy:  authenticate(lending_policy.MakeUpdateEndpoint(s)),
+		DeleteLendingPolicy:  authenticate(lending_policy.MakeDeleteEndpoint(s)),
+
+		FindAllTag:        authenticate(tag.MakeFindAllEndpoint(s)),
+		AddTagToBook:      authenticate(tag.MakeAddToBookEndpoint(s)),
+		RemoveTagFromBook: authenticate(tag.MakeRemoveFromBookEndpoint(s)),
+	}
+
+	return traced(e)
+}
+
+// traced wraps every endpoint of e in a span named after its field
+func traced(e Endpoints) Endpoints {
+	v := reflect.ValueOf(&e).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		ep, ok := v.Field(i).Interface().(endpoint.Endpoint)
+		if !ok || ep == nil {
+			continue
+		}
+		name := v.Type().Field(i).Name
+		v.Field(i).Set(reflect.ValueOf(trace.EndpointMiddleware(name)(ep)))
 	}
+	return e
 }
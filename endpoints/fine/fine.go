@@ -0,0 +1,104 @@
+package fine
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+)
+
+// BalanceRequest request struct for balance of a user
+type BalanceRequest struct {
+	UserID domain.UUID
+}
+
+// BalanceResponse response struct for balance of a user
+type BalanceResponse struct {
+	Balance *domain.Balance `json:"balance"`
+}
+
+// MakeBalanceEndpoint make endpoint for balance of a user
+func MakeBalanceEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var userFind domain.User
+		req := request.(BalanceRequest)
+		userFind.ID = req.UserID
+
+		balance, err := s.FineService.Balance(ctx, &userFind)
+		if err != nil {
+			return nil, err
+		}
+		return BalanceResponse{Balance: balance}, nil
+	}
+}
+
+// LedgerRequest request struct for ledger of a user
+type LedgerRequest struct {
+	UserID domain.UUID
+}
+
+// LedgerResponse response struct for ledger of a user
+type LedgerResponse struct {
+	LedgerEntries []domain.LedgerEntry `json:"ledger_entries"`
+}
+
+// MakeLedgerEndpoint make endpoint for ledger of a user
+func MakeLedgerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var userFind domain.User
+		req := request.(LedgerRequest)
+		userFind.ID = req.UserID
+
+		entries, err := s.FineService.FindAllByUser(ctx, &userFind)
+		if err != nil {
+			return nil, err
+		}
+		return LedgerResponse{LedgerEntries: entries}, nil
+	}
+}
+
+// PayData data for Pay
+type PayData struct {
+	UserID domain.UUID `json:"-"`
+	Amount int64       `json:"amount"`
+	Note   string      `json:"note"`
+}
+
+// PayRequest request struct for Pay
+type PayRequest struct {
+	Payment PayData `json:"payment"`
+}
+
+// PayResponse response struct for Pay
+type PayResponse struct {
+	Payment domain.LedgerEntry `json:"payment"`
+}
+
+// StatusCode customstatus code for success Pay
+func (PayResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+// MakePayEndpoint make endpoint for a payment of user
+func MakePayEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req     = request.(PayRequest)
+			payment = &domain.LedgerEntry{
+				UserID: req.Payment.UserID,
+				Amount: req.Payment.Amount,
+				Note:   req.Payment.Note,
+			}
+		)
+
+		err := s.FineService.Pay(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+
+		return PayResponse{Payment: *payment}, nil
+	}
+}
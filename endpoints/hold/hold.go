@@ -0,0 +1,105 @@
+package hold
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+)
+
+// CreateData data for CreateHold
+type CreateData struct {
+	BookID domain.UUID `json:"-"`
+	UserID domain.UUID `json:"user_id"`
+}
+
+// CreateRequest request struct for CreateHold
+type CreateRequest struct {
+	Hold CreateData `json:"hold"`
+}
+
+// CreateResponse response struct for CreateHold
+type CreateResponse struct {
+	Hold domain.Hold `json:"hold"`
+}
+
+// StatusCode customstatus code for success create Hold
+func (CreateResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+// MakeCreateEndpoint make endpoint for create a Hold
+func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req  = request.(CreateRequest)
+			hold = &domain.Hold{
+				BookID: req.Hold.BookID,
+				UserID: req.Hold.UserID,
+			}
+		)
+
+		err := s.HoldService.Create(ctx, hold)
+		if err != nil {
+			return nil, err
+		}
+
+		return CreateResponse{Hold: *hold}, nil
+	}
+}
+
+// FindAllByUserRequest request struct for find all Hold of a user
+type FindAllByUserRequest struct {
+	UserID domain.UUID
+}
+
+// FindAllByUserResponse response struct for find all Hold of a user
+type FindAllByUserResponse struct {
+	Holds []domain.Hold `json:"holds"`
+}
+
+// MakeFindAllByUserEndpoint make endpoint for find all Hold of a user
+func MakeFindAllByUserEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var userFind domain.User
+		req := request.(FindAllByUserRequest)
+		userFind.ID = req.UserID
+
+		holds, err := s.HoldService.FindAllByUser(ctx, &userFind)
+		if err != nil {
+			return nil, err
+		}
+		return FindAllByUserResponse{Holds: holds}, nil
+	}
+}
+
+// CancelRequest request struct for cancel a Hold
+type CancelRequest struct {
+	HoldID domain.UUID
+}
+
+// CancelResponse response struct for cancel a Hold
+type CancelResponse struct {
+	Hold domain.Hold `json:"hold"`
+}
+
+// MakeCancelEndpoint make endpoint for cancel a Hold
+func MakeCancelEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			holdFind = domain.Hold{}
+			req      = request.(CancelRequest)
+		)
+		holdFind.ID = req.HoldID
+
+		res, err := s.HoldService.Cancel(ctx, &holdFind)
+		if err != nil {
+			return nil, err
+		}
+
+		return CancelResponse{Hold: *res}, nil
+	}
+}
@@ -13,10 +13,11 @@ import (
 
 // CreateData data for CreateLendBook
 type CreateData struct {
-	BookID domain.UUID `json:"book_id"`
-	UserID domain.UUID `json:"user_id"`
-	From   time.Time   `json:"from"`
-	To     time.Time   `json:"to"`
+	BookID     domain.UUID `json:"book_id"`
+	BookCopyID domain.UUID `json:"book_copy_id"`
+	UserID     domain.UUID `json:"user_id"`
+	From       time.Time   `json:"from"`
+	To         time.Time   `json:"to"`
 }
 
 // CreateRequest request struct for CreateLendBook
@@ -41,10 +42,11 @@ func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
 		var (
 			req      = request.(CreateRequest)
 			lendBook = &domain.LendBook{
-				BookID: req.LendBook.BookID,
-				UserID: req.LendBook.UserID,
-				From:   req.LendBook.From,
-				To:     req.LendBook.To,
+				BookID:     req.LendBook.BookID,
+				BookCopyID: req.LendBook.BookCopyID,
+				UserID:     req.LendBook.UserID,
+				From:       req.LendBook.From,
+				To:         req.LendBook.To,
 			}
 		)
 		err := s.LendBookService.Create(ctx, lendBook)
@@ -56,6 +58,59 @@ func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
 	}
 }
 
+// CreateBatchData data for CreateBatchLendBook, a lend book is
+// created for each of BookIDs
+type CreateBatchData struct {
+	UserID  domain.UUID   `json:"user_id"`
+	BookIDs []domain.UUID `json:"book_ids"`
+	From    time.Time     `json:"from"`
+	To      time.Time     `json:"to"`
+}
+
+// CreateBatchRequest request struct for CreateBatchLendBook
+type CreateBatchRequest struct {
+	LendBookBatch CreateBatchData `json:"lend_book_batch"`
+}
+
+// CreateBatchResponse response struct for CreateBatchLendBook
+type CreateBatchResponse struct {
+	LendBooks []domain.LendBook `json:"lend_books"`
+}
+
+// StatusCode customstatus code for success create batch of LendBook
+func (CreateBatchResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+// MakeCreateBatchEndpoint make endpoint for create a batch of LendBook
+func MakeCreateBatchEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req       = request.(CreateBatchRequest)
+			lendBooks = make([]*domain.LendBook, 0, len(req.LendBookBatch.BookIDs))
+		)
+		for _, bookID := range req.LendBookBatch.BookIDs {
+			lendBooks = append(lendBooks, &domain.LendBook{
+				BookID: bookID,
+				UserID: req.LendBookBatch.UserID,
+				From:   req.LendBookBatch.From,
+				To:     req.LendBookBatch.To,
+			})
+		}
+
+		err := s.LendBookService.CreateBatch(ctx, lendBooks)
+		if err != nil {
+			return nil, err
+		}
+
+		res := CreateBatchResponse{LendBooks: make([]domain.LendBook, 0, len(lendBooks))}
+		for _, lendBook := range lendBooks {
+			res.LendBooks = append(res.LendBooks, *lendBook)
+		}
+		return res, nil
+	}
+}
+
 // FindRequest request struct for Find a LendBook
 type FindRequest struct {
 	LendBookID domain.UUID
@@ -82,32 +137,37 @@ func MakeFindEndPoint(s service.Service) endpoint.Endpoint {
 }
 
 // FindAllRequest request struct for FindAll LendBook
-type FindAllRequest struct{}
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
 
 // FindAllResponse request struct for find all LendBook
 type FindAllResponse struct {
 	LendBooks []domain.LendBook `json:"lend_books"`
+	domain.ListMeta
 }
 
 // MakeFindAllEndpoint make endpoint for find all LendBook
 func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		_ = request.(FindAllRequest)
-		lend_books, err := s.LendBookService.FindAll(ctx)
+		req := request.(FindAllRequest)
+		lend_books, meta, err := s.LendBookService.FindAll(ctx, &req.Query)
 		if err != nil {
 			return nil, err
 		}
-		return FindAllResponse{LendBooks: lend_books}, nil
+		return FindAllResponse{LendBooks: lend_books, ListMeta: *meta}, nil
 	}
 }
 
 // UpdateData data for Create
 type UpdateData struct {
-	ID     domain.UUID `json:"-"`
-	BookID domain.UUID `json:"book_id"`
-	UserID domain.UUID `json:"user_id"`
-	From   time.Time   `json:"from"`
-	To     time.Time   `json:"to"`
+	ID         domain.UUID           `json:"-"`
+	BookID     domain.UUID           `json:"book_id"`
+	BookCopyID domain.UUID           `json:"book_copy_id"`
+	UserID     domain.UUID           `json:"user_id"`
+	From       time.Time             `json:"from"`
+	To         time.Time             `json:"to"`
+	Status     domain.LendBookStatus `json:"status"`
 }
 
 // UpdateRequest request struct for update
@@ -126,11 +186,13 @@ func MakeUpdateEndpoint(s service.Service) endpoint.Endpoint {
 		var (
 			req      = request.(UpdateRequest)
 			lendBook = domain.LendBook{
-				Model:  domain.Model{ID: req.LendBook.ID},
-				BookID: req.LendBook.BookID,
-				UserID: req.LendBook.UserID,
-				From:   req.LendBook.From,
-				To:     req.LendBook.To,
+				Model:      domain.Model{ID: req.LendBook.ID},
+				BookID:     req.LendBook.BookID,
+				BookCopyID: req.LendBook.BookCopyID,
+				UserID:     req.LendBook.UserID,
+				From:       req.LendBook.From,
+				To:         req.LendBook.To,
+				Status:     req.LendBook.Status,
 			}
 		)
 		res, err := s.LendBookService.Update(ctx, &lendBook)
@@ -169,3 +231,59 @@ func MakeDeleteEndpoint(s service.Service) endpoint.Endpoint {
 		return DeleteResponse{"success"}, nil
 	}
 }
+
+// ReturnRequest request struct for return book of a LendBook
+type ReturnRequest struct {
+	LendBookID domain.UUID
+}
+
+// ReturnResponse response struct for return book of a LendBook
+type ReturnResponse struct {
+	LendBook domain.LendBook `json:"lend_Book"`
+}
+
+// MakeReturnEndpoint make endpoint for return book of a LendBook
+func MakeReturnEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			lendBookFind = domain.LendBook{}
+			req          = request.(ReturnRequest)
+		)
+		lendBookFind.ID = req.LendBookID
+
+		res, err := s.LendBookService.Return(ctx, &lendBookFind)
+		if err != nil {
+			return nil, err
+		}
+
+		return ReturnResponse{LendBook: *res}, nil
+	}
+}
+
+// RenewRequest request struct for renew a LendBook
+type RenewRequest struct {
+	LendBookID domain.UUID
+}
+
+// RenewResponse response struct for renew a LendBook
+type RenewResponse struct {
+	LendBook domain.LendBook `json:"lend_Book"`
+}
+
+// MakeRenewEndpoint make endpoint for renew a LendBook
+func MakeRenewEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			lendBookFind = domain.LendBook{}
+			req          = request.(RenewRequest)
+		)
+		lendBookFind.ID = req.LendBookID
+
+		res, err := s.LendBookService.Renew(ctx, &lendBookFind)
+		if err != nil {
+			return nil, err
+		}
+
+		return RenewResponse{LendBook: *res}, nil
+	}
+}
@@ -0,0 +1,186 @@
+package lending_policy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+)
+
+// CreateData data for CreateLendingPolicy
+type CreateData struct {
+	Name           string                `json:"name"`
+	CategoryID     domain.UUID           `json:"category_id"`
+	Tier           domain.MembershipTier `json:"tier"`
+	LoanPeriodDays int                   `json:"loan_period_days"`
+	MaxActiveLoans int                   `json:"max_active_loans"`
+	MaxRenewals    int                   `json:"max_renewals"`
+	FinePerDay     int64                 `json:"fine_per_day"`
+}
+
+// CreateRequest request struct for CreateLendingPolicy
+type CreateRequest struct {
+	LendingPolicy CreateData `json:"lending_policy"`
+}
+
+// CreateResponse response struct for CreateLendingPolicy
+type CreateResponse struct {
+	LendingPolicy domain.LendingPolicy `json:"lending_policy"`
+}
+
+// StatusCode customstatus code for success create LendingPolicy
+func (CreateResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+// MakeCreateEndpoint make endpoint for create a LendingPolicy
+func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req    = request.(CreateRequest)
+			policy = &domain.LendingPolicy{
+				Name:           req.LendingPolicy.Name,
+				CategoryID:     req.LendingPolicy.CategoryID,
+				Tier:           req.LendingPolicy.Tier,
+				LoanPeriodDays: req.LendingPolicy.LoanPeriodDays,
+				MaxActiveLoans: req.LendingPolicy.MaxActiveLoans,
+				MaxRenewals:    req.LendingPolicy.MaxRenewals,
+				FinePerDay:     req.LendingPolicy.FinePerDay,
+			}
+		)
+
+		err := s.LendingPolicyService.Create(ctx, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		return CreateResponse{LendingPolicy: *policy}, nil
+	}
+}
+
+// FindRequest request struct for Find a LendingPolicy
+type FindRequest struct {
+	LendingPolicyID domain.UUID
+}
+
+// FindResponse response struct for Find a LendingPolicy
+type FindResponse struct {
+	LendingPolicy *domain.LendingPolicy `json:"lending_policy"`
+}
+
+// MakeFindEndPoint make endpoint for find LendingPolicy
+func MakeFindEndPoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var policyFind domain.LendingPolicy
+		req := request.(FindRequest)
+		policyFind.ID = req.LendingPolicyID
+
+		policy, err := s.LendingPolicyService.Find(ctx, &policyFind)
+		if err != nil {
+			return nil, err
+		}
+		return FindResponse{LendingPolicy: policy}, nil
+	}
+}
+
+// FindAllRequest request struct for FindAll LendingPolicy
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
+
+// FindAllResponse request struct for find all LendingPolicy
+type FindAllResponse struct {
+	LendingPolicies []domain.LendingPolicy `json:"lending_policies"`
+	domain.ListMeta
+}
+
+// MakeFindAllEndpoint make endpoint for find all LendingPolicy
+func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(FindAllRequest)
+		policies, meta, err := s.LendingPolicyService.FindAll(ctx, &req.Query)
+		if err != nil {
+			return nil, err
+		}
+		return FindAllResponse{LendingPolicies: policies, ListMeta: *meta}, nil
+	}
+}
+
+// UpdateData data for Update
+type UpdateData struct {
+	ID             domain.UUID           `json:"-"`
+	Name           string                `json:"name"`
+	CategoryID     domain.UUID           `json:"category_id"`
+	Tier           domain.MembershipTier `json:"tier"`
+	LoanPeriodDays int                   `json:"loan_period_days"`
+	MaxActiveLoans int                   `json:"max_active_loans"`
+	MaxRenewals    int                   `json:"max_renewals"`
+	FinePerDay     int64                 `json:"fine_per_day"`
+}
+
+// UpdateRequest request struct for update
+type UpdateRequest struct {
+	LendingPolicy UpdateData `json:"lending_policy"`
+}
+
+// UpdateResponse response struct for Update
+type UpdateResponse struct {
+	LendingPolicy domain.LendingPolicy `json:"lending_policy"`
+}
+
+// MakeUpdateEndpoint make endpoint for update a LendingPolicy
+func MakeUpdateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req    = request.(UpdateRequest)
+			policy = domain.LendingPolicy{
+				Model:          domain.Model{ID: req.LendingPolicy.ID},
+				Name:           req.LendingPolicy.Name,
+				CategoryID:     req.LendingPolicy.CategoryID,
+				Tier:           req.LendingPolicy.Tier,
+				LoanPeriodDays: req.LendingPolicy.LoanPeriodDays,
+				MaxActiveLoans: req.LendingPolicy.MaxActiveLoans,
+				MaxRenewals:    req.LendingPolicy.MaxRenewals,
+				FinePerDay:     req.LendingPolicy.FinePerDay,
+			}
+		)
+
+		res, err := s.LendingPolicyService.Update(ctx, &policy)
+		if err != nil {
+			return nil, err
+		}
+
+		return UpdateResponse{LendingPolicy: *res}, nil
+	}
+}
+
+// DeleteRequest request struct for delete a LendingPolicy
+type DeleteRequest struct {
+	LendingPolicyID domain.UUID
+}
+
+// DeleteResponse response struct for delete a LendingPolicy
+type DeleteResponse struct {
+	Status string `json:"status"`
+}
+
+// MakeDeleteEndpoint make endpoint for delete a LendingPolicy
+func MakeDeleteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			policyFind = domain.LendingPolicy{}
+			req        = request.(DeleteRequest)
+		)
+		policyFind.ID = req.LendingPolicyID
+
+		err := s.LendingPolicyService.Delete(ctx, &policyFind)
+		if err != nil {
+			return nil, err
+		}
+
+		return DeleteResponse{"success"}, nil
+	}
+}
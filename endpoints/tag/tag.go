@@ -0,0 +1,85 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service"
+)
+
+// AddToBookData data for AddTagToBook
+type AddToBookData struct {
+	BookID domain.UUID `json:"-"`
+	Tags   []string    `json:"tags"`
+}
+
+// AddToBookRequest request struct for AddTagToBook
+type AddToBookRequest struct {
+	Book AddToBookData `json:"book"`
+}
+
+// BookTagsResponse response struct for tags of a Book
+type BookTagsResponse struct {
+	Tags []domain.Tag `json:"tags"`
+}
+
+// MakeAddToBookEndpoint make endpoint for add tags to a Book
+func MakeAddToBookEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var bookFind domain.Book
+		req := request.(AddToBookRequest)
+		bookFind.ID = req.Book.BookID
+
+		tags, err := s.TagService.AddToBook(ctx, &bookFind, req.Book.Tags)
+		if err != nil {
+			return nil, err
+		}
+		return BookTagsResponse{Tags: tags}, nil
+	}
+}
+
+// RemoveFromBookRequest request struct for remove a tag from a Book
+type RemoveFromBookRequest struct {
+	BookID domain.UUID
+	Tag    string
+}
+
+// MakeRemoveFromBookEndpoint make endpoint for remove a tag from a Book
+func MakeRemoveFromBookEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var bookFind domain.Book
+		req := request.(RemoveFromBookRequest)
+		bookFind.ID = req.BookID
+
+		tags, err := s.TagService.RemoveFromBook(ctx, &bookFind, []string{req.Tag})
+		if err != nil {
+			return nil, err
+		}
+		return BookTagsResponse{Tags: tags}, nil
+	}
+}
+
+// FindAllRequest request struct for FindAll Tag
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
+
+// FindAllResponse request struct for find all Tag
+type FindAllResponse struct {
+	Tags []domain.Tag `json:"tags"`
+	domain.ListMeta
+}
+
+// MakeFindAllEndpoint make endpoint for find all Tag
+func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(FindAllRequest)
+		tags, meta, err := s.TagService.FindAll(ctx, &req.Query)
+		if err != nil {
+			return nil, err
+		}
+		return FindAllResponse{Tags: tags, ListMeta: *meta}, nil
+	}
+}
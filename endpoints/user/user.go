@@ -12,8 +12,11 @@ import (
 
 // CreateData data for CreateUser
 type CreateData struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name     string                `json:"name"`
+	Email    string                `json:"email"`
+	Tier     domain.MembershipTier `json:"tier"`
+	Role     domain.Role           `json:"role"`
+	Password string                `json:"password"`
 }
 
 // CreateRequest request struct for CreateUser
@@ -37,8 +40,11 @@ func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
 		var (
 			req  = request.(CreateRequest)
 			user = &domain.User{
-				Name:  req.User.Name,
-				Email: req.User.Email,
+				Name:     req.User.Name,
+				Email:    req.User.Email,
+				Tier:     req.User.Tier,
+				Role:     req.User.Role,
+				Password: req.User.Password,
 			}
 		)
 
@@ -77,30 +83,36 @@ func MakeFindEndPoint(s service.Service) endpoint.Endpoint {
 }
 
 // FindAllRequest request struct for FindAll User
-type FindAllRequest struct{}
+type FindAllRequest struct {
+	Query domain.ListQuery
+}
 
 // FindAllResponse request struct for find all User
 type FindAllResponse struct {
 	Users []domain.User `json:"users"`
+	domain.ListMeta
 }
 
 // MakeFindAllEndpoint make endpoint for find all User
 func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		_ = request.(FindAllRequest)
-		users, err := s.UserService.FindAll(ctx)
+		req := request.(FindAllRequest)
+		users, meta, err := s.UserService.FindAll(ctx, &req.Query)
 		if err != nil {
 			return nil, err
 		}
-		return FindAllResponse{Users: users}, nil
+		return FindAllResponse{Users: users, ListMeta: *meta}, nil
 	}
 }
 
 // UpdateData data for Create
 type UpdateData struct {
-	ID    domain.UUID `json:"-"`
-	Name  string      `json:"name"`
-	Email string      `json:"email"`
+	ID       domain.UUID           `json:"-"`
+	Name     string                `json:"name"`
+	Email    string                `json:"email"`
+	Tier     domain.MembershipTier `json:"tier"`
+	Role     domain.Role           `json:"role"`
+	Password string                `json:"password"`
 }
 
 // UpdateRequest request struct for update
@@ -119,9 +131,12 @@ func MakeUpdateEndpoint(s service.Service) endpoint.Endpoint {
 		var (
 			req  = request.(UpdateRequest)
 			user = domain.User{
-				Model: domain.Model{ID: req.User.ID},
-				Name:  req.User.Name,
-				Email: req.User.Email,
+				Model:    domain.Model{ID: req.User.ID},
+				Name:     req.User.Name,
+				Email:    req.User.Email,
+				Tier:     req.User.Tier,
+				Role:     req.User.Role,
+				Password: req.User.Password,
 			}
 		)
 
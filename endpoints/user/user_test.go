@@ -131,8 +131,8 @@ func TestMakeFindUserEndPoint(t *testing.T) {
 func TestMakeFindAllEndpoint(t *testing.T) {
 	mock := service.Service{
 		UserService: &userService.ServiceMock{
-			FindAllFunc: func(_ context.Context) ([]domain.User, error) {
-				return []domain.User{}, nil
+			FindAllFunc: func(_ context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error) {
+				return []domain.User{}, &domain.ListMeta{}, nil
 			},
 		},
 	}
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose"
+)
+
+// PingDB check db accepts connections
+func PingDB(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// MigrationVersion check version of db migrated by goose is want, an
+// instance running against an older or newer schema is not ready
+func MigrationVersion(db *sql.DB, want int64) Check {
+	return func(ctx context.Context) error {
+		got, err := dbVersion(ctx, db)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("database is at migration %d, want %d", got, want)
+		}
+		return nil
+	}
+}
+
+// dbVersion read current version of db as goose does, the latest row of a
+// version tells whether it is applied or rolled back. Unlike goose, the
+// version table is never created here
+func dbVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	rows, err := db.QueryContext(ctx,
+		fmt.Sprintf("SELECT version_id, is_applied FROM %s ORDER BY id DESC", goose.TableName()))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	skipped := map[int64]bool{}
+	for rows.Next() {
+		var (
+			version int64
+			applied bool
+		)
+		if err := rows.Scan(&version, &applied); err != nil {
+			return 0, err
+		}
+		if skipped[version] {
+			continue
+		}
+		if applied {
+			return version, nil
+		}
+		skipped[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+// LatestMigration return version of the latest migration file in dir
+func LatestMigration(dir string) (int64, error) {
+	migrations, err := goose.CollectMigrations(dir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+	return last.Version, nil
+}
@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check report a dependency is unusable by an error, it must return once
+// ctx is done
+type Check func(ctx context.Context) error
+
+// Status of a check and of all checks of Checker
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// CheckResult is result of a check in response of Checker
+type CheckResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Result is response of Checker
+type Result struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// ErrDraining is error of check "shutdown" once Checker is drained
+var ErrDraining = errors.New("server is shutting down")
+
+// Checker run named checks concurrently, each one is given timeout
+type Checker struct {
+	timeout  time.Duration
+	names    []string
+	checks   []Check
+	draining int32
+}
+
+// NewChecker create Checker giving each check timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add add check name to c, it must not be called once c serves requests
+func (c *Checker) Add(name string, check Check) *Checker {
+	c.names = append(c.names, name)
+	c.checks = append(c.checks, check)
+	return c
+}
+
+// Drain make c fail from now on, so traffic stops being routed to a server
+// which is shutting down while it finishes requests in flight
+func (c *Checker) Drain() {
+	atomic.StoreInt32(&c.draining, 1)
+}
+
+// Run run every check of c, the result is ok only when all checks pass
+func (c *Checker) Run(ctx context.Context) Result {
+	res := Result{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(c.checks)+1),
+	}
+	if atomic.LoadInt32(&c.draining) == 1 {
+		res.Status = StatusUnavailable
+		res.Checks["shutdown"] = CheckResult{Status: StatusUnavailable, Duration: "0s", Error: ErrDraining.Error()}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i := range c.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(ctx)
+			r := CheckResult{Status: StatusOK, Duration: time.Since(start).String()}
+			if err != nil {
+				r.Status = StatusUnavailable
+				r.Error = err.Error()
+			}
+
+			mu.Lock()
+			res.Checks[name] = r
+			if err != nil {
+				res.Status = StatusUnavailable
+			}
+			mu.Unlock()
+		}(c.names[i], c.checks[i])
+	}
+	wg.Wait()
+
+	return res
+}
+
+// ServeHTTP write result of checks as JSON, its status is 503 when a check
+// fails so traffic is not routed to the instance
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := c.Run(r.Context())
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if res.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker_ServeHTTP(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name       string
+		checker    *Checker
+		wantCode   int
+		wantStatus map[string]string
+	}{
+		{
+			name:       "no checks",
+			checker:    NewChecker(time.Second),
+			wantCode:   http.StatusOK,
+			wantStatus: map[string]string{},
+		},
+		{
+			name:       "all checks pass",
+			checker:    NewChecker(time.Second).Add("database", ok).Add("migration", ok),
+			wantCode:   http.StatusOK,
+			wantStatus: map[string]string{"database": StatusOK, "migration": StatusOK},
+		},
+		{
+			name:       "a check fails",
+			checker:    NewChecker(time.Second).Add("database", down).Add("migration", ok),
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: map[string]string{"database": StatusUnavailable, "migration": StatusOK},
+		},
+		{
+			name:       "a check times out",
+			checker:    NewChecker(10*time.Millisecond).Add("database", slow),
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: map[string]string{"database": StatusUnavailable},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.checker.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Checker.ServeHTTP() code = %v, want %v", w.Code, tt.wantCode)
+			}
+			var res Result
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("Checker.ServeHTTP() body error = %v", err)
+			}
+			if len(res.Checks) != len(tt.wantStatus) {
+				t.Fatalf("Checker.ServeHTTP() checks = %v, want %v", res.Checks, tt.wantStatus)
+			}
+			for name, status := range tt.wantStatus {
+				if res.Checks[name].Status != status {
+					t.Errorf("Checker.ServeHTTP() check %v = %v, want %v", name, res.Checks[name].Status, status)
+				}
+			}
+		})
+	}
+}
+
+func TestLatestMigration(t *testing.T) {
+	got, err := LatestMigration("../cmd/migrator/migration")
+	if err != nil {
+		t.Fatalf("LatestMigration() error = %v", err)
+	}
+	if got < 1 {
+		t.Errorf("LatestMigration() = %v, want a version", got)
+	}
+
+	if _, err := LatestMigration("not_exist"); err == nil {
+		t.Errorf("LatestMigration() of missing directory error = nil")
+	}
+}
+
+func TestChecker_Drain(t *testing.T) {
+	c := NewChecker(time.Second).Add("database", func(ctx context.Context) error { return nil })
+	if res := c.Run(context.Background()); res.Status != StatusOK {
+		t.Fatalf("Checker.Run() status = %v, want %v", res.Status, StatusOK)
+	}
+
+	c.Drain()
+	res := c.Run(context.Background())
+	if res.Status != StatusUnavailable {
+		t.Errorf("Checker.Run() status after Drain() = %v, want %v", res.Status, StatusUnavailable)
+	}
+	if res.Checks["shutdown"].Error != ErrDraining.Error() {
+		t.Errorf("Checker.Run() shutdown check = %v, want error %v", res.Checks["shutdown"], ErrDraining)
+	}
+}
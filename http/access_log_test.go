@@ -0,0 +1,36 @@
+// +build unit
+
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func Test_accessLogMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	h := accessLogMiddleware(log.NewLogfmtLogger(&buf))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/books", nil)
+	r.Header.Set(requestIDHeader, "abc")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get(requestIDHeader); got != "abc" {
+		t.Errorf("accessLogMiddleware() request ID header = %v, want %v", got, "abc")
+	}
+	line := buf.String()
+	for _, want := range []string{"request_id=abc", "method=POST", "path=/books", "status=201", "bytes=5"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("accessLogMiddleware() logged %q, want it contains %q", line, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/phungvandat/example-go/domain"
+	apiKeySvc "github.com/phungvandat/example-go/service/api_key"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+// apiKeyHeader is header which machine clients send their API key in
+const apiKeyHeader = "X-API-Key"
+
+// requestScope return scope which request r needs, its resource is the first
+// segment of path and its action is read for safe methods, write for others
+func requestScope(r *http.Request) string {
+	resource := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0]
+	action := domain.ScopeActionWrite
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		action = domain.ScopeActionRead
+	}
+	return domain.Scope(resource, action)
+}
+
+// apiKeyMiddleware authenticate requests which have an API key by s, the key
+// must have scope of the request. Its principal is put in context of request,
+// so endpoints take it in place of bearer token
+func apiKeyMiddleware(s apiKeySvc.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(apiKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// path of request is instance of problem when it fails
+			ctx := context.WithValue(r.Context(), kithttp.ContextKeyRequestPath, r.URL.Path)
+			apiKey, err := s.Verify(ctx, key)
+			if err != nil {
+				encodeError(ctx, err, w)
+				return
+			}
+			if !apiKey.HasScope(requestScope(r)) {
+				encodeError(ctx, apiKeySvc.ErrMissingScope, w)
+				return
+			}
+
+			ctx = auth.NewContext(r.Context(), &auth.Principal{
+				Role:     domain.RoleLibrarian,
+				APIKeyID: apiKey.ID,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// +build unit
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+	apiKeySvc "github.com/phungvandat/example-go/service/api_key"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+func Test_apiKeyMiddleware(t *testing.T) {
+	keyID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	apiKeys := &apiKeySvc.ServiceMock{
+		VerifyFunc: func(_ context.Context, key string) (*domain.APIKey, error) {
+			if key != "egk_valid" {
+				return nil, apiKeySvc.ErrInvalidAPIKey
+			}
+			return &domain.APIKey{Model: domain.Model{ID: keyID}, Scopes: []string{"books:write"}}, nil
+		},
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		key           string
+		wantStatus    int
+		wantPrincipal bool
+	}{
+		{
+			name:       "request without key is passed",
+			method:     http.MethodGet,
+			path:       "/users",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "key with scope of request",
+			method:        http.MethodPost,
+			path:          "/books/batch",
+			key:           "egk_valid",
+			wantStatus:    http.StatusOK,
+			wantPrincipal: true,
+		},
+		{
+			name:          "key with write scope reads",
+			method:        http.MethodGet,
+			path:          "/books",
+			key:           "egk_valid",
+			wantStatus:    http.StatusOK,
+			wantPrincipal: true,
+		},
+		{
+			name:       "key without scope of request",
+			method:     http.MethodPost,
+			path:       "/lend_books",
+			key:        "egk_valid",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid key",
+			method:     http.MethodGet,
+			path:       "/books",
+			key:        "egk_invalid",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPrincipal *auth.Principal
+			h := apiKeyMiddleware(apiKeys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPrincipal, _ = auth.FromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.key != "" {
+				r.Header.Set(apiKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("apiKeyMiddleware() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if (gotPrincipal != nil) != tt.wantPrincipal {
+				t.Errorf("apiKeyMiddleware() principal = %v, want principal %v", gotPrincipal, tt.wantPrincipal)
+			}
+			if gotPrincipal != nil && gotPrincipal.APIKeyID != keyID {
+				t.Errorf("apiKeyMiddleware() principal API key = %v, want %v", gotPrincipal.APIKeyID, keyID)
+			}
+		})
+	}
+}
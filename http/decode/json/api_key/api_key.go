@@ -0,0 +1,38 @@
+package api_key
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/domain"
+	apiKeyEndpoint "github.com/phungvandat/example-go/endpoints/api_key"
+	"github.com/phungvandat/example-go/http/decode/json/query"
+)
+
+// CreateRequest .
+func CreateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req apiKeyEndpoint.CreateRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
+// FindAllRequest .
+func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return apiKeyEndpoint.FindAllRequest{Query: q}, nil
+}
+
+// RevokeRequest .
+func RevokeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	apiKeyID, err := domain.UUIDFromString(chi.URLParam(r, "api_key_id"))
+	if err != nil {
+		return nil, err
+	}
+	return apiKeyEndpoint.RevokeRequest{APIKeyID: apiKeyID}, nil
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	authEndpoint "github.com/phungvandat/example-go/endpoints/auth"
+	authSvc "github.com/phungvandat/example-go/service/auth"
+)
+
+// LoginRequest .
+func LoginRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req authEndpoint.LoginRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
+// RefreshRequest .
+func RefreshRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req authEndpoint.RefreshRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
+// BearerToken put bearer token of Authorization header into context
+func BearerToken(ctx context.Context, r *http.Request) context.Context {
+	const prefix = "bearer "
+	h := r.Header.Get("Authorization")
+	if len(h) < len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return ctx
+	}
+	return authSvc.NewTokenContext(ctx, strings.TrimSpace(h[len(prefix):]))
+}
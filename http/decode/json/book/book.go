@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi"
 
 	"github.com/phungvandat/example-go/domain"
 	bookEndpoint "github.com/phungvandat/example-go/endpoints/book"
+	"github.com/phungvandat/example-go/http/decode/json/query"
+	"github.com/phungvandat/example-go/service/list"
 )
 
 // FindRequest .
@@ -22,7 +25,24 @@ func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 // FindAllRequest .
 func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return bookEndpoint.FindAllRequest{}, nil
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return bookEndpoint.FindAllRequest{Query: q}, nil
+}
+
+// SearchRequest .
+func SearchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := bookEndpoint.SearchRequest{Query: r.URL.Query().Get("q")}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, list.ErrInvalidLimit
+		}
+		req.Limit = n
+	}
+	return req, nil
 }
 
 // CreateRequest .
@@ -32,6 +52,13 @@ func CreateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, err
 }
 
+// CreateBatchRequest .
+func CreateBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req bookEndpoint.CreateBatchRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
 // UpdateRequest .
 func UpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	bookID, err := domain.UUIDFromString(chi.URLParam(r, "book_id"))
@@ -0,0 +1,65 @@
+package book_copy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/domain"
+	bookCopyEndpoint "github.com/phungvandat/example-go/endpoints/book_copy"
+	"github.com/phungvandat/example-go/http/decode/json/query"
+)
+
+// FindRequest .
+func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	bookCopyID, err := domain.UUIDFromString(chi.URLParam(r, "book_copy_id"))
+	if err != nil {
+		return nil, err
+	}
+	return bookCopyEndpoint.FindRequest{BookCopyID: bookCopyID}, nil
+}
+
+// FindAllRequest .
+func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return bookCopyEndpoint.FindAllRequest{Query: q}, nil
+}
+
+// CreateRequest .
+func CreateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req bookCopyEndpoint.CreateRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
+// UpdateRequest .
+func UpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	bookCopyID, err := domain.UUIDFromString(chi.URLParam(r, "book_copy_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req bookCopyEndpoint.UpdateRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.BookCopy.ID = bookCopyID
+
+	return req, nil
+}
+
+// DeleteRequest .
+func DeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	bookCopyID, err := domain.UUIDFromString(chi.URLParam(r, "book_copy_id"))
+	if err != nil {
+		return nil, err
+	}
+	return bookCopyEndpoint.DeleteRequest{BookCopyID: bookCopyID}, nil
+}
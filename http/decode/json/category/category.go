@@ -9,6 +9,7 @@ import (
 
 	"github.com/phungvandat/example-go/domain"
 	categoryEndpoint "github.com/phungvandat/example-go/endpoints/category"
+	"github.com/phungvandat/example-go/http/decode/json/query"
 )
 
 // FindRequest .
@@ -22,7 +23,11 @@ func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 // FindAllRequest .
 func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return categoryEndpoint.FindAllRequest{}, nil
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return categoryEndpoint.FindAllRequest{Query: q}, nil
 }
 
 // CreateRequest .
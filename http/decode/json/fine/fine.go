@@ -0,0 +1,48 @@
+package fine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/domain"
+	fineEndpoint "github.com/phungvandat/example-go/endpoints/fine"
+)
+
+// BalanceRequest .
+func BalanceRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	userID, err := domain.UUIDFromString(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, err
+	}
+	return fineEndpoint.BalanceRequest{UserID: userID}, nil
+}
+
+// LedgerRequest .
+func LedgerRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	userID, err := domain.UUIDFromString(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, err
+	}
+	return fineEndpoint.LedgerRequest{UserID: userID}, nil
+}
+
+// PayRequest .
+func PayRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	userID, err := domain.UUIDFromString(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req fineEndpoint.PayRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Payment.UserID = userID
+
+	return req, nil
+}
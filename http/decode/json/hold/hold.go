@@ -0,0 +1,48 @@
+package hold
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/domain"
+	holdEndpoint "github.com/phungvandat/example-go/endpoints/hold"
+)
+
+// CreateRequest .
+func CreateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	bookID, err := domain.UUIDFromString(chi.URLParam(r, "book_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req holdEndpoint.CreateRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Hold.BookID = bookID
+
+	return req, nil
+}
+
+// FindAllByUserRequest .
+func FindAllByUserRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	userID, err := domain.UUIDFromString(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, err
+	}
+	return holdEndpoint.FindAllByUserRequest{UserID: userID}, nil
+}
+
+// CancelRequest .
+func CancelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	holdID, err := domain.UUIDFromString(chi.URLParam(r, "hold_id"))
+	if err != nil {
+		return nil, err
+	}
+	return holdEndpoint.CancelRequest{HoldID: holdID}, nil
+}
@@ -9,11 +9,12 @@ import (
 
 	"github.com/phungvandat/example-go/domain"
 	lendBookEndpoint "github.com/phungvandat/example-go/endpoints/lend_book"
+	"github.com/phungvandat/example-go/http/decode/json/query"
 )
 
 // FindRequest .
 func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lendBook_id"))
+	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lend_book_id"))
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +23,11 @@ func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 // FindAllRequest .
 func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return lendBookEndpoint.FindAllRequest{}, nil
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return lendBookEndpoint.FindAllRequest{Query: q}, nil
 }
 
 // CreateRequest .
@@ -32,6 +37,13 @@ func CreateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, err
 }
 
+// CreateBatchRequest .
+func CreateBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req lendBookEndpoint.CreateBatchRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
 // UpdateRequest .
 func UpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lend_book_id"))
@@ -52,9 +64,27 @@ func UpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 // DeleteRequest .
 func DeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lendBook_id"))
+	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lend_book_id"))
 	if err != nil {
 		return nil, err
 	}
 	return lendBookEndpoint.DeleteRequest{LendBookID: lendBookID}, nil
 }
+
+// ReturnRequest .
+func ReturnRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lend_book_id"))
+	if err != nil {
+		return nil, err
+	}
+	return lendBookEndpoint.ReturnRequest{LendBookID: lendBookID}, nil
+}
+
+// RenewRequest .
+func RenewRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	lendBookID, err := domain.UUIDFromString(chi.URLParam(r, "lend_book_id"))
+	if err != nil {
+		return nil, err
+	}
+	return lendBookEndpoint.RenewRequest{LendBookID: lendBookID}, nil
+}
@@ -0,0 +1,65 @@
+package lending_policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/domain"
+	policyEndpoint "github.com/phungvandat/example-go/endpoints/lending_policy"
+	"github.com/phungvandat/example-go/http/decode/json/query"
+)
+
+// FindRequest .
+func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	policyID, err := domain.UUIDFromString(chi.URLParam(r, "policy_id"))
+	if err != nil {
+		return nil, err
+	}
+	return policyEndpoint.FindRequest{LendingPolicyID: policyID}, nil
+}
+
+// FindAllRequest .
+func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return policyEndpoint.FindAllRequest{Query: q}, nil
+}
+
+// CreateRequest .
+func CreateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req policyEndpoint.CreateRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
+// UpdateRequest .
+func UpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	policyID, err := domain.UUIDFromString(chi.URLParam(r, "policy_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req policyEndpoint.UpdateRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.LendingPolicy.ID = policyID
+
+	return req, nil
+}
+
+// DeleteRequest .
+func DeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	policyID, err := domain.UUIDFromString(chi.URLParam(r, "policy_id"))
+	if err != nil {
+		return nil, err
+	}
+	return policyEndpoint.DeleteRequest{LendingPolicyID: policyID}, nil
+}
@@ -0,0 +1,52 @@
+// Package query decode list query which is shared by FindAll requests
+package query
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// ListQuery decode list query from URL query of r, e.g.
+// ?name=go&sort=-created_at,name&limit=10&cursor=...
+// Parameters other than sort, limit and cursor are filters.
+func ListQuery(r *http.Request) (domain.ListQuery, error) {
+	values := r.URL.Query()
+	q := domain.ListQuery{
+		Filters: map[string][]string{},
+		Cursor:  values.Get("cursor"),
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return q, list.ErrInvalidLimit
+		}
+		q.Limit = n
+	}
+
+	for _, field := range strings.Split(values.Get("sort"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if strings.HasPrefix(field, "-") {
+			q.Sort = append(q.Sort, domain.SortField{Field: field[1:], Desc: true})
+		} else {
+			q.Sort = append(q.Sort, domain.SortField{Field: field})
+		}
+	}
+
+	for name, value := range values {
+		switch name {
+		case "sort", "limit", "cursor":
+		default:
+			q.Filters[name] = value
+		}
+	}
+
+	return q, nil
+}
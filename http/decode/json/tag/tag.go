@@ -0,0 +1,58 @@
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/domain"
+	tagEndpoint "github.com/phungvandat/example-go/endpoints/tag"
+	"github.com/phungvandat/example-go/http/decode/json/query"
+)
+
+// AddToBookRequest .
+func AddToBookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	bookID, err := domain.UUIDFromString(chi.URLParam(r, "book_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req tagEndpoint.AddToBookRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Book.BookID = bookID
+
+	return req, nil
+}
+
+// RemoveFromBookRequest .
+func RemoveFromBookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	bookID, err := domain.UUIDFromString(chi.URLParam(r, "book_id"))
+	if err != nil {
+		return nil, err
+	}
+	// router matches escaped path, so tag may be escaped
+	tag, err := url.PathUnescape(chi.URLParam(r, "tag"))
+	if err != nil {
+		return nil, err
+	}
+	return tagEndpoint.RemoveFromBookRequest{
+		BookID: bookID,
+		Tag:    tag,
+	}, nil
+}
+
+// FindAllRequest .
+func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return tagEndpoint.FindAllRequest{Query: q}, nil
+}
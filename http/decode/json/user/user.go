@@ -9,6 +9,7 @@ import (
 
 	"github.com/phungvandat/example-go/domain"
 	userEndpoint "github.com/phungvandat/example-go/endpoints/user"
+	"github.com/phungvandat/example-go/http/decode/json/query"
 )
 
 // FindRequest .
@@ -22,7 +23,11 @@ func FindRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 // FindAllRequest .
 func FindAllRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return userEndpoint.FindAllRequest{}, nil
+	q, err := query.ListQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	return userEndpoint.FindAllRequest{Query: q}, nil
 }
 
 // CreateRequest .
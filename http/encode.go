@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
 )
 
 // encodeResponse is the common method to encode all response types to the client.
@@ -13,14 +15,27 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, response interfa
 	return kithttp.EncodeJSONResponse(ctx, w, response)
 }
 
+// problem is body of error response described by RFC 7807,
+// clients branch on Code which is stable
+type problem struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail"`
+	Instance string      `json:"instance,omitempty"`
+	Code     string      `json:"code"`
+	Errors   interface{} `json:"errors,omitempty"`
+}
+
+// problemTypePrefix prefix code of error to make type of problem
+const problemTypePrefix = "urn:example-go:problem:"
+
 func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
-	// maybe we can be smart here by returning text/json error based on request's
-	// content-type header
-	encodeJSONError(ctx, err, w)
+	encodeProblem(ctx, contextError(ctx, err), w)
 }
 
-func encodeJSONError(_ context.Context, err error, w http.ResponseWriter) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+func encodeProblem(ctx context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
 	// custom headers
 	if headerer, ok := err.(kithttp.Headerer); ok {
 		for k, values := range headerer.Headers() {
@@ -29,14 +44,23 @@ func encodeJSONError(_ context.Context, err error, w http.ResponseWriter) {
 			}
 		}
 	}
-	code := http.StatusInternalServerError
-	// custome code
-	if sc, ok := err.(kithttp.StatusCoder); ok {
-		code = sc.StatusCode()
+	code := apperror.StatusOf(err)
+
+	p := problem{
+		Title:  http.StatusText(code),
+		Status: code,
+		Detail: err.Error(),
+		Code:   apperror.CodeOf(err, code),
+	}
+	p.Type = problemTypePrefix + p.Code
+	if path, ok := ctx.Value(kithttp.ContextKeyRequestPath).(string); ok {
+		p.Instance = path
 	}
+	// error made of errors of parts lists them, e.g. errors of fields
+	if detailer, ok := err.(apperror.Detailer); ok {
+		p.Errors = detailer.Details()
+	}
+
 	w.WriteHeader(code)
-	// enforce json response
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": err.Error(),
-	})
+	json.NewEncoder(w).Encode(p)
 }
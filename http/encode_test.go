@@ -0,0 +1,78 @@
+// +build unit
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/phungvandat/example-go/service/category"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+func Test_encodeProblem(t *testing.T) {
+	errs := validation.Errors{}
+	errs.Add("name", validation.CodeRequired, category.ErrNameIsRequired)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantErrors int
+	}{
+		{
+			name:       "error of service",
+			err:        category.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "category.not_found",
+		},
+		{
+			name:       "errors of fields",
+			err:        errs.Err(),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "validation_failed",
+			wantErrors: 1,
+		},
+		{
+			name:       "error without code",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), kithttp.ContextKeyRequestPath, "/categories/1")
+			w := httptest.NewRecorder()
+			encodeProblem(ctx, tt.err, w)
+
+			if got := w.Header().Get("Content-Type"); got != "application/problem+json; charset=utf-8" {
+				t.Errorf("encodeProblem() content type = %v", got)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("encodeProblem() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			var got struct {
+				problem
+				Errors []interface{} `json:"errors"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode || got.Type != problemTypePrefix+tt.wantCode ||
+				got.Title != http.StatusText(tt.wantStatus) || got.Detail != tt.err.Error() || got.Instance != "/categories/1" {
+				t.Errorf("encodeProblem() = %+v", got.problem)
+			}
+			if len(got.Errors) != tt.wantErrors {
+				t.Errorf("encodeProblem() errors = %v, want %v", got.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
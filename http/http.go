@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/cors"
@@ -10,34 +11,65 @@ import (
 	httptransport "github.com/go-kit/kit/transport/http"
 
 	"github.com/phungvandat/example-go/endpoints"
+	"github.com/phungvandat/example-go/health"
+	apiKeyDecode "github.com/phungvandat/example-go/http/decode/json/api_key"
+	authDecode "github.com/phungvandat/example-go/http/decode/json/auth"
 	bookDecode "github.com/phungvandat/example-go/http/decode/json/book"
+	bookCopyDecode "github.com/phungvandat/example-go/http/decode/json/book_copy"
 	categoryDecode "github.com/phungvandat/example-go/http/decode/json/category"
+	fineDecode "github.com/phungvandat/example-go/http/decode/json/fine"
+	holdDecode "github.com/phungvandat/example-go/http/decode/json/hold"
 	lendBookDecode "github.com/phungvandat/example-go/http/decode/json/lend_book"
+	policyDecode "github.com/phungvandat/example-go/http/decode/json/lending_policy"
+	tagDecode "github.com/phungvandat/example-go/http/decode/json/tag"
 	userDecode "github.com/phungvandat/example-go/http/decode/json/user"
+	apiKeySvc "github.com/phungvandat/example-go/service/api_key"
 )
 
 // NewHTTPHandler ...
 func NewHTTPHandler(endpoints endpoints.Endpoints,
+	apiKeys apiKeySvc.Service,
+	readiness *health.Checker,
 	logger log.Logger,
-	useCORS bool) http.Handler {
+	corsOrigins []string,
+	requestTimeout time.Duration) http.Handler {
 	r := chi.NewRouter()
+	r.Use(accessLogMiddleware(logger))
+	r.Use(timeoutMiddleware(requestTimeout))
 
-	// if running on local (using `make dev`), include cors middleware
-	if useCORS {
+	// browsers of allowed origins may call API, any origin is allowed on
+	// local (using `make dev`)
+	if len(corsOrigins) > 0 {
 		cors := cors.New(cors.Options{
-			AllowedOrigins:   []string{"*"},
+			AllowedOrigins:   corsOrigins,
 			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", apiKeyHeader, requestIDHeader, traceParentHeader},
+			ExposedHeaders:   []string{requestIDHeader},
 			AllowCredentials: true,
 		})
 		r.Use(cors.Handler)
 	}
 
+	// machine clients authenticate by API key in place of login
+	r.Use(apiKeyMiddleware(apiKeys))
+
 	options := []httptransport.ServerOption{
 		httptransport.ServerErrorLogger(logger),
 		httptransport.ServerErrorEncoder(encodeError),
+		// path of request is instance of problem when it fails
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		// bearer token is verified by endpoints which require authentication
+		httptransport.ServerBefore(authDecode.BearerToken),
+		// each request is a span, endpoints and queries are its children
+		httptransport.ServerBefore(startServerSpan),
+		httptransport.ServerFinalizer(finishServerSpan),
 	}
 
+	// liveness only tells the process serves requests, readiness tells its
+	// dependencies are usable so traffic may be routed to it
+	r.Get("/healthz", health.NewChecker(0).ServeHTTP)
+	r.Get("/readyz", readiness.ServeHTTP)
+
 	r.Get("/_warm", httptransport.NewServer(
 		endpoint.Nop,
 		httptransport.NopRequestDecoder,
@@ -45,6 +77,42 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 		options...,
 	).ServeHTTP)
 
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/login", httptransport.NewServer(
+			endpoints.LoginAuth,
+			authDecode.LoginRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/refresh", httptransport.NewServer(
+			endpoints.RefreshAuth,
+			authDecode.RefreshRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+	})
+
+	r.Route("/api_keys", func(r chi.Router) {
+		r.Get("/", httptransport.NewServer(
+			endpoints.FindAllAPIKey,
+			apiKeyDecode.FindAllRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/", httptransport.NewServer(
+			endpoints.CreateAPIKey,
+			apiKeyDecode.CreateRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Delete("/{api_key_id}", httptransport.NewServer(
+			endpoints.RevokeAPIKey,
+			apiKeyDecode.RevokeRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+	})
+
 	r.Route("/users", func(r chi.Router) {
 		r.Get("/", httptransport.NewServer(
 			endpoints.FindAllUser,
@@ -76,6 +144,30 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			encodeResponse,
 			options...,
 		).ServeHTTP)
+		r.Get("/{user_id}/holds", httptransport.NewServer(
+			endpoints.FindAllHoldByUser,
+			holdDecode.FindAllByUserRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Get("/{user_id}/balance", httptransport.NewServer(
+			endpoints.BalanceUser,
+			fineDecode.BalanceRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Get("/{user_id}/ledger", httptransport.NewServer(
+			endpoints.LedgerUser,
+			fineDecode.LedgerRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/{user_id}/payments", httptransport.NewServer(
+			endpoints.PayUser,
+			fineDecode.PayRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
 	})
 
 	r.Route("/categories", func(r chi.Router) {
@@ -118,6 +210,12 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			encodeResponse,
 			options...,
 		).ServeHTTP)
+		r.Get("/search", httptransport.NewServer(
+			endpoints.SearchBook,
+			bookDecode.SearchRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
 		r.Get("/{book_id}", httptransport.NewServer(
 			endpoints.FindBook,
 			bookDecode.FindRequest,
@@ -130,6 +228,12 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			encodeResponse,
 			options...,
 		).ServeHTTP)
+		r.Post("/batch", httptransport.NewServer(
+			endpoints.CreateBatchBook,
+			bookDecode.CreateBatchRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
 		r.Put("/{book_id}", httptransport.NewServer(
 			endpoints.UpdateBook,
 			bookDecode.UpdateRequest,
@@ -142,6 +246,108 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			encodeResponse,
 			options...,
 		).ServeHTTP)
+		r.Post("/{book_id}/holds", httptransport.NewServer(
+			endpoints.CreateHold,
+			holdDecode.CreateRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/{book_id}/tags", httptransport.NewServer(
+			endpoints.AddTagToBook,
+			tagDecode.AddToBookRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Delete("/{book_id}/tags/{tag}", httptransport.NewServer(
+			endpoints.RemoveTagFromBook,
+			tagDecode.RemoveFromBookRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+	})
+
+	r.Route("/tags", func(r chi.Router) {
+		r.Get("/", httptransport.NewServer(
+			endpoints.FindAllTag,
+			tagDecode.FindAllRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+	})
+
+	r.Route("/book_copies", func(r chi.Router) {
+		r.Get("/", httptransport.NewServer(
+			endpoints.FindAllBookCopy,
+			bookCopyDecode.FindAllRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Get("/{book_copy_id}", httptransport.NewServer(
+			endpoints.FindBookCopy,
+			bookCopyDecode.FindRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/", httptransport.NewServer(
+			endpoints.CreateBookCopy,
+			bookCopyDecode.CreateRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Put("/{book_copy_id}", httptransport.NewServer(
+			endpoints.UpdateBookCopy,
+			bookCopyDecode.UpdateRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Delete("/{book_copy_id}", httptransport.NewServer(
+			endpoints.DeleteBookCopy,
+			bookCopyDecode.DeleteRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+	})
+
+	r.Route("/policies", func(r chi.Router) {
+		r.Get("/", httptransport.NewServer(
+			endpoints.FindAllLendingPolicy,
+			policyDecode.FindAllRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Get("/{policy_id}", httptransport.NewServer(
+			endpoints.FindLendingPolicy,
+			policyDecode.FindRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/", httptransport.NewServer(
+			endpoints.CreateLendingPolicy,
+			policyDecode.CreateRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Put("/{policy_id}", httptransport.NewServer(
+			endpoints.UpdateLendingPolicy,
+			policyDecode.UpdateRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Delete("/{policy_id}", httptransport.NewServer(
+			endpoints.DeleteLendingPolicy,
+			policyDecode.DeleteRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+	})
+
+	r.Route("/holds", func(r chi.Router) {
+		r.Delete("/{hold_id}", httptransport.NewServer(
+			endpoints.CancelHold,
+			holdDecode.CancelRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
 	})
 
 	r.Route("/lend_books", func(r chi.Router) {
@@ -152,7 +358,7 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			options...,
 		).ServeHTTP)
 		r.Get("/{lend_book_id}", httptransport.NewServer(
-			endpoints.FindAllLendBook,
+			endpoints.FindLendBook,
 			lendBookDecode.FindRequest,
 			encodeResponse,
 			options...,
@@ -163,6 +369,12 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			encodeResponse,
 			options...,
 		).ServeHTTP)
+		r.Post("/batch", httptransport.NewServer(
+			endpoints.CreateBatchLendBook,
+			lendBookDecode.CreateBatchRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
 		r.Put("/{lend_book_id}", httptransport.NewServer(
 			endpoints.UpdateLendBook,
 			lendBookDecode.UpdateRequest,
@@ -175,6 +387,18 @@ func NewHTTPHandler(endpoints endpoints.Endpoints,
 			encodeResponse,
 			options...,
 		).ServeHTTP)
+		r.Post("/{lend_book_id}/return", httptransport.NewServer(
+			endpoints.ReturnLendBook,
+			lendBookDecode.ReturnRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
+		r.Post("/{lend_book_id}/renew", httptransport.NewServer(
+			endpoints.RenewLendBook,
+			lendBookDecode.RenewRequest,
+			encodeResponse,
+			options...,
+		).ServeHTTP)
 	})
 
 	return r
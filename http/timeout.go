@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// StatusClientClosedRequest is status of a request which client cancels
+// before its response, as nginx logs it
+const StatusClientClosedRequest = 499
+
+// errors of requests whose context is done before their response
+var (
+	errRequestCanceled = apperror.New("request_canceled", StatusClientClosedRequest, "request is canceled by client")
+	errRequestTimeout  = apperror.New("request_timeout", http.StatusGatewayTimeout, "request is not done in time")
+)
+
+// timeoutMiddleware cancel context of request after d, so statements of
+// database which run longer are canceled. A zero d has no timeout
+func timeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// contextError return error of ctx in place of err when ctx is done, as
+// statements fail in ways of driver when they are canceled. Errors having
+// own status code are kept
+func contextError(ctx context.Context, err error) error {
+	if _, ok := err.(kithttp.StatusCoder); ok {
+		return err
+	}
+	if err == context.DeadlineExceeded || ctx.Err() == context.DeadlineExceeded {
+		return errRequestTimeout
+	}
+	if err == context.Canceled || ctx.Err() == context.Canceled {
+		return errRequestCanceled
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+// +build unit
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/phungvandat/example-go/service/category"
+)
+
+func Test_contextError(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	boom := errors.New("pq: canceling statement due to user request")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want error
+	}{
+		{"context is not done", context.Background(), boom, boom},
+		{"client cancels", canceled, boom, errRequestCanceled},
+		{"request times out", expired, boom, errRequestTimeout},
+		{"deadline of statement", context.Background(), context.DeadlineExceeded, errRequestTimeout},
+		{"error of service is kept", canceled, category.ErrNotFound, category.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contextError(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("contextError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_timeoutMiddleware(t *testing.T) {
+	h := timeoutMiddleware(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		encodeError(r.Context(), r.Context().Err(), w)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("timeoutMiddleware() status = %v, want %v", w.Code, http.StatusGatewayTimeout)
+	}
+}
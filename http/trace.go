@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+
+	"github.com/phungvandat/example-go/trace"
+)
+
+// traceParentHeader carry span of caller as of W3C Trace Context, a request
+// with it joins trace of caller
+const traceParentHeader = "traceparent"
+
+// startServerSpan start span of transport for r, it is finished by
+// finishServerSpan when response is written
+func startServerSpan(ctx context.Context, r *http.Request) context.Context {
+	if c, err := trace.ParseTraceParent(r.Header.Get(traceParentHeader)); err == nil {
+		ctx = trace.NewRemoteContext(ctx, c)
+	}
+
+	// route pattern keeps IDs out of span name
+	path := r.URL.Path
+	if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+		path = rctx.RoutePattern()
+	}
+
+	ctx, span := trace.StartSpan(ctx, "HTTP "+r.Method+" "+path, trace.KindServer)
+	span.SetTag("http.method", r.Method)
+	span.SetTag("http.path", r.URL.Path)
+	return ctx
+}
+
+// finishServerSpan finish span of transport with code of response
+func finishServerSpan(ctx context.Context, code int, r *http.Request) {
+	span := trace.FromContext(ctx)
+	span.SetTag("http.status_code", strconv.Itoa(code))
+	if code >= http.StatusInternalServerError {
+		span.SetError(errStatus(code))
+	}
+	span.Finish()
+}
+
+// errStatus is error of span which ends by a server error
+type errStatus int
+
+func (e errStatus) Error() string {
+	return http.StatusText(int(e))
+}
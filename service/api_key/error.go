@@ -0,0 +1,19 @@
+package api_key
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrNotFound        = apperror.New("api_key.not_found", http.StatusNotFound, "record not found")
+	ErrIDIsRequired    = apperror.New("api_key.id_is_required", http.StatusBadRequest, "ID of API key is required")
+	ErrNameIsRequired  = apperror.New("api_key.name_is_required", http.StatusBadRequest, "Name of API key is required")
+	ErrScopeIsRequired = apperror.New("api_key.scope_is_required", http.StatusBadRequest, "At least one scope is required")
+	ErrScopeIsInvalid  = apperror.New("api_key.scope_is_invalid", http.StatusBadRequest, "Scope is invalid, it must be <resource>:read or <resource>:write")
+	ErrRevokedAPIKey   = apperror.New("api_key.revoked_api_key", http.StatusConflict, "API key is revoked already")
+	ErrInvalidAPIKey   = apperror.New("api_key.invalid_api_key", http.StatusUnauthorized, "API key is invalid or revoked")
+	ErrMissingScope    = apperror.New("api_key.missing_scope", http.StatusForbidden, "API key has no scope for the request")
+)
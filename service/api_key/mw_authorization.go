@@ -0,0 +1,41 @@
+package api_key
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits only admins to manage API keys, keys are
+// verified for any caller since they authenticate the caller
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, apiKey *domain.APIKey) error {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, apiKey)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.APIKey, *domain.ListMeta, error) {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw authorizationMiddleware) Revoke(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error) {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return mw.Service.Revoke(ctx, apiKey)
+}
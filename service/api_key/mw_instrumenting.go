@@ -0,0 +1,63 @@
+package api_key
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "api_key"),
+			errors:   errors.With("service", "api_key"),
+			latency:  latency.With("service", "api_key"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Create(ctx context.Context, apiKey *domain.APIKey) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Create", begin, err)
+	}(time.Now())
+	return mw.Service.Create(ctx, apiKey)
+}
+func (mw instrumentingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.APIKey, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAll", begin, err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw instrumentingMiddleware) Revoke(ctx context.Context, apiKey *domain.APIKey) (res *domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Revoke", begin, err)
+	}(time.Now())
+	return mw.Service.Revoke(ctx, apiKey)
+}
+func (mw instrumentingMiddleware) Verify(ctx context.Context, key string) (res *domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Verify", begin, err)
+	}(time.Now())
+	return mw.Service.Verify(ctx, key)
+}
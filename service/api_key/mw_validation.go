@@ -0,0 +1,62 @@
+package api_key
+
+import (
+	"context"
+	"strings"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw validationMiddleware) Create(ctx context.Context, apiKey *domain.APIKey) error {
+	errs := validation.Errors{}
+	apiKey.Name = strings.TrimSpace(apiKey.Name)
+	if apiKey.Name == "" {
+		errs.Add("name", validation.CodeRequired, ErrNameIsRequired)
+	}
+	if len(apiKey.Scopes) == 0 {
+		errs.Add("scopes", validation.CodeRequired, ErrScopeIsRequired)
+	}
+	for _, scope := range apiKey.Scopes {
+		if !domain.IsValidScope(scope) {
+			errs.Add("scopes", validation.CodeInvalid, ErrScopeIsInvalid)
+			break
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, apiKey)
+}
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.APIKey, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "revoked"); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw validationMiddleware) Revoke(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error) {
+	if apiKey.ID.IsZero() {
+		return nil, ErrIDIsRequired
+	}
+	return mw.Service.Revoke(ctx, apiKey)
+}
+func (mw validationMiddleware) Verify(ctx context.Context, key string) (*domain.APIKey, error) {
+	if key == "" {
+		return nil, ErrInvalidAPIKey
+	}
+	return mw.Service.Verify(ctx, key)
+}
@@ -0,0 +1,79 @@
+package api_key
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_Create(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CreateFunc: func(_ context.Context, p *domain.APIKey) error {
+			return nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.APIKey
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid API key",
+			args: args{&domain.APIKey{Name: "kiosk", Scopes: []string{"books:read", "lend_books:write"}}},
+		},
+		{
+			name:            "invalid API key by missing name",
+			args:            args{&domain.APIKey{Name: "  ", Scopes: []string{"books:read"}}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "invalid API key by missing scopes",
+			args:            args{&domain.APIKey{Name: "kiosk"}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "invalid API key by unknown scope",
+			args:            args{&domain.APIKey{Name: "kiosk", Scopes: []string{"books:delete"}}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			err := mw.Create(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Create() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Create() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package api_key
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// keyPrefix mark keys of this API, so leaked ones are easy to spot
+const keyPrefix = "egk_"
+
+// lastUsedPrecision is how often last used time of a key is written, so a
+// busy key does not write on every request
+const lastUsedPrecision = time.Minute
+
+// pgService implmenter for APIKey serivce in postgres
+type pgService struct {
+	db *gorm.DB
+}
+
+// NewPGService create new PGService
+func NewPGService(db *gorm.DB) Service {
+	return &pgService{
+		db: db,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// hashKey hash key for lookup, keys are random so a salt is not needed
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create implement Create for APIKey service, the key is set to p in plain
+// text and it can not be read again
+func (s *pgService) Create(ctx context.Context, p *domain.APIKey) error {
+	s = s.withContext(ctx)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	p.Key = keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	p.Prefix = p.Key[:len(keyPrefix)+8]
+	p.KeyHash = hashKey(p.Key)
+	if principal, ok := auth.FromContext(ctx); ok {
+		p.CreatedBy = principal.UserID
+	}
+	return s.db.Create(p).Error
+}
+
+// FindAll implement FindAll for APIKey service
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.APIKey, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	switch q.Filter("revoked") {
+	case "true":
+		db = db.Where("revoked_at IS NOT NULL")
+	case "false":
+		db = db.Where("revoked_at IS NULL")
+	}
+
+	res := []domain.APIKey{}
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, meta, nil
+}
+
+// Revoke implement Revoke for APIKey service, revoked keys are kept for auditing
+func (s *pgService) Revoke(ctx context.Context, p *domain.APIKey) (*domain.APIKey, error) {
+	s = s.withContext(ctx)
+	old := domain.APIKey{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Find(&old).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if old.RevokedAt != nil {
+		return nil, ErrRevokedAPIKey
+	}
+
+	now := time.Now()
+	old.RevokedAt = &now
+	return &old, s.db.Model(&old).Update("revoked_at", now).Error
+}
+
+// Verify implement Verify for APIKey service, last used time of the key is
+// updated when it is valid
+func (s *pgService) Verify(ctx context.Context, key string) (*domain.APIKey, error) {
+	s = s.withContext(ctx)
+	res := domain.APIKey{}
+	err := s.db.Where("key_hash = ? AND revoked_at IS NULL", hashKey(key)).First(&res).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if res.LastUsedAt == nil || now.Sub(*res.LastUsedAt) >= lastUsedPrecision {
+		res.LastUsedAt = &now
+		if err := s.db.Model(&res).UpdateColumn("last_used_at", now).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
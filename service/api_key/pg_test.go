@@ -0,0 +1,67 @@
+package api_key
+
+import (
+	"context"
+	"testing"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestPGService_Verify(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	s := &pgService{
+		db: testDB,
+	}
+	active := domain.APIKey{Name: "kiosk", Scopes: []string{"books:read"}}
+	if err := s.Create(context.Background(), &active); err != nil {
+		t.Fatalf("Failed to create API key by error %v", err)
+	}
+	revoked := domain.APIKey{Name: "import", Scopes: []string{"books:write"}}
+	if err := s.Create(context.Background(), &revoked); err != nil {
+		t.Fatalf("Failed to create API key by error %v", err)
+	}
+	if _, err := s.Revoke(context.Background(), &revoked); err != nil {
+		t.Fatalf("Failed to revoke API key by error %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{
+			name: "success verify active key",
+			key:  active.Key,
+		},
+		{
+			name:    "failed verify by revoked key",
+			key:     revoked.Key,
+			wantErr: ErrInvalidAPIKey,
+		},
+		{
+			name:    "failed verify by unknown key",
+			key:     "egk_unknown",
+			wantErr: ErrInvalidAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Verify(context.Background(), tt.key)
+			if err != tt.wantErr {
+				t.Errorf("pgService.Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.ID != active.ID || got.LastUsedAt == nil) {
+				t.Errorf("pgService.Verify() = %v, want key %v which is used", got, active.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package api_key
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// sorting is fields which list of API keys can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
+// Service interface for project service
+type Service interface {
+	Create(ctx context.Context, p *domain.APIKey) error
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.APIKey, *domain.ListMeta, error)
+	Revoke(ctx context.Context, p *domain.APIKey) (*domain.APIKey, error)
+	Verify(ctx context.Context, key string) (*domain.APIKey, error)
+}
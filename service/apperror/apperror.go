@@ -0,0 +1,63 @@
+// Package apperror declare errors of services with stable codes, so clients
+// branch on codes instead of messages. A code must not change once released.
+package apperror
+
+import (
+	"net/http"
+)
+
+// Coder is implemented by errors having a stable code
+type Coder interface {
+	Code() string
+}
+
+// Detailer is implemented by errors made of errors of parts, e.g. fields
+type Detailer interface {
+	Details() interface{}
+}
+
+// Error is an error of service with its code and status code
+type Error struct {
+	code    string
+	status  int
+	message string
+}
+
+// New create an error with code, status code and message
+func New(code string, status int, message string) *Error {
+	return &Error{code: code, status: status, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// StatusCode return status code of error
+func (e *Error) StatusCode() int {
+	return e.status
+}
+
+// Code return code of error
+func (e *Error) Code() string {
+	return e.code
+}
+
+// StatusOf return status code of err, an error without status code is internal
+func StatusOf(err error) int {
+	if sc, ok := err.(interface{ StatusCode() int }); ok {
+		return sc.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeOf return code of err, an error without code is internal
+// or is named by its status code
+func CodeOf(err error, status int) string {
+	if coder, ok := err.(Coder); ok {
+		return coder.Code()
+	}
+	if status == http.StatusInternalServerError {
+		return "internal"
+	}
+	return "unknown"
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Require return principal of ctx when its role is one of roles, any role is
+// permitted when roles is empty
+func Require(ctx context.Context, roles ...domain.Role) (*Principal, error) {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrMissingToken
+	}
+	if len(roles) == 0 {
+		return p, nil
+	}
+	for _, role := range roles {
+		if p.Role == role {
+			return p, nil
+		}
+	}
+	return nil, ErrForbidden
+}
+
+// RequireStaff return principal of ctx when it is an admin or a librarian
+func RequireStaff(ctx context.Context) (*Principal, error) {
+	return Require(ctx, domain.RoleAdmin, domain.RoleLibrarian)
+}
+
+// RequireSelfOrStaff return principal of ctx when it is the user of userID or
+// a staff, members may only act on their own
+func RequireSelfOrStaff(ctx context.Context, userID domain.UUID) (*Principal, error) {
+	p, err := Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Role.IsStaff() && p.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return p, nil
+}
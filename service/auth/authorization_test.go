@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestRequireSelfOrStaff(t *testing.T) {
+	memberID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	otherID := domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		userID  domain.UUID
+		wantErr error
+	}{
+		{
+			name:   "member acts on own",
+			ctx:    NewContext(context.Background(), &Principal{UserID: memberID, Role: domain.RoleMember}),
+			userID: memberID,
+		},
+		{
+			name:    "member acts on other",
+			ctx:     NewContext(context.Background(), &Principal{UserID: memberID, Role: domain.RoleMember}),
+			userID:  otherID,
+			wantErr: ErrForbidden,
+		},
+		{
+			name:   "librarian acts on other",
+			ctx:    NewContext(context.Background(), &Principal{UserID: memberID, Role: domain.RoleLibrarian}),
+			userID: otherID,
+		},
+		{
+			name:   "system acts on other",
+			ctx:    NewSystemContext(context.Background()),
+			userID: otherID,
+		},
+		{
+			name:    "caller is not authenticated",
+			ctx:     context.Background(),
+			userID:  memberID,
+			wantErr: ErrMissingToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RequireSelfOrStaff(tt.ctx, tt.userID); err != tt.wantErr {
+				t.Errorf("RequireSelfOrStaff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	member := NewContext(context.Background(), &Principal{Role: domain.RoleMember})
+	if _, err := Require(member); err != nil {
+		t.Errorf("Require() error = %v, wantErr %v", err, nil)
+	}
+	if _, err := RequireStaff(member); err != ErrForbidden {
+		t.Errorf("RequireStaff() error = %v, wantErr %v", err, ErrForbidden)
+	}
+	librarian := NewContext(context.Background(), &Principal{Role: domain.RoleLibrarian})
+	if _, err := Require(librarian, domain.RoleAdmin); err != ErrForbidden {
+		t.Errorf("Require() error = %v, wantErr %v", err, ErrForbidden)
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Principal is the caller who is authenticated by access token or API key,
+// an API key acts as a librarian within its scopes and has no user
+type Principal struct {
+	UserID   domain.UUID
+	Email    string
+	Role     domain.Role
+	APIKeyID domain.UUID
+}
+
+type contextKey int
+
+const (
+	principalKey contextKey = iota
+	tokenKey
+)
+
+// NewContext return ctx carrying principal p
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// FromContext return principal of ctx, it is false when caller is not authenticated
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey).(*Principal)
+	return p, ok
+}
+
+// NewSystemContext return ctx of jobs which are run by server itself, they are
+// permitted as an admin
+func NewSystemContext(ctx context.Context) context.Context {
+	return NewContext(ctx, &Principal{Role: domain.RoleAdmin})
+}
+
+// NewTokenContext return ctx carrying bearer token of request
+func NewTokenContext(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey, token)
+}
+
+// TokenFromContext return bearer token of ctx, it is empty when request has none
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey).(string)
+	return token
+}
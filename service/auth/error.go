@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrEmailIsRequired        = apperror.New("auth.email_is_required", http.StatusBadRequest, "Email is required")
+	ErrPasswordIsRequired     = apperror.New("auth.password_is_required", http.StatusBadRequest, "Password is required")
+	ErrRefreshTokenIsRequired = apperror.New("auth.refresh_token_is_required", http.StatusBadRequest, "Refresh token is required")
+	ErrInvalidCredentials     = apperror.New("auth.invalid_credentials", http.StatusUnauthorized, "Email or password is incorrect")
+	ErrMissingToken           = apperror.New("auth.missing_token", http.StatusUnauthorized, "Bearer token is required")
+	ErrInvalidToken           = apperror.New("auth.invalid_token", http.StatusUnauthorized, "Token is invalid")
+	ErrTokenExpired           = apperror.New("auth.token_expired", http.StatusUnauthorized, "Token is expired")
+	ErrForbidden              = apperror.New("auth.forbidden", http.StatusForbidden, "Permission denied")
+)
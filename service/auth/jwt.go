@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Lifetimes of tokens
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// Kinds of token, an access token can not be used to refresh and vice versa
+const (
+	kindAccess  = "access"
+	kindRefresh = "refresh"
+)
+
+// claims is payload of JWT
+type claims struct {
+	Subject   domain.UUID `json:"sub"`
+	Email     string      `json:"email"`
+	Role      domain.Role `json:"role"`
+	Kind      string      `json:"kind"`
+	IssuedAt  int64       `json:"iat"`
+	ExpiresAt int64       `json:"exp"`
+}
+
+// Valid implement jwt.Claims, claims are checked by parse since expiry
+// depends on time which is given and kind on token which is expected
+func (claims) Valid() error {
+	return nil
+}
+
+// Signer sign and verify JWT by HS256 with a secret
+type Signer struct {
+	secret []byte
+	parser *jwt.Parser
+}
+
+// NewSigner create Signer with secret
+func NewSigner(secret string) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+		// a token signed by other algorithm, none included, is rejected
+		// before its signature is checked
+		parser: &jwt.Parser{ValidMethods: []string{jwt.SigningMethodHS256.Alg()}},
+	}
+}
+
+func (s *Signer) sign(c claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(s.secret)
+}
+
+// parse verify token of kind at time now and return its claims, a token
+// is expired from second of its exp on (RFC 7519 section 4.1.4)
+func (s *Signer) parse(token, kind string, now time.Time) (*claims, error) {
+	c := claims{}
+	_, err := s.parser.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	})
+	if err != nil || c.Kind != kind {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= c.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	return &c, nil
+}
+
+// issue sign access and refresh tokens of user at time now
+func (s *Signer) issue(user *domain.User, now time.Time) (*domain.Token, error) {
+	access, err := s.sign(claims{
+		Subject:   user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		Kind:      kindAccess,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(AccessTokenTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.sign(claims{
+		Subject:   user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		Kind:      kindRefresh,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(RefreshTokenTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Token{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(AccessTokenTTL.Seconds()),
+	}, nil
+}
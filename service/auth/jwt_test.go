@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestSigner_parse(t *testing.T) {
+	signer := NewSigner("secret")
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := &domain.User{
+		Model: domain.Model{ID: domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")},
+		Email: "example@gmail.com",
+	}
+	token, err := signer.issue(user, now)
+	if err != nil {
+		t.Fatalf("Failed to issue token by error %v", err)
+	}
+	tampered := strings.Split(token.AccessToken, ".")
+	tampered[1] = tampered[1] + "e30"
+
+	parts := strings.Split(token.AccessToken, ".")
+	tamperedSignature := parts[0] + "." + parts[1] + "." + strings.ToUpper(parts[2])
+
+	// unsigned token which claims the same payload
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	noneToken := noneHeader + "." + parts[1] + "."
+
+	// token signed with the same secret by other algorithm
+	otherAlgToken, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims{
+		Subject:   user.ID,
+		Kind:      kindAccess,
+		ExpiresAt: now.Add(AccessTokenTTL).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Failed to sign token by error %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		signer  *Signer
+		token   string
+		kind    string
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:   "valid access token",
+			signer: signer,
+			token:  token.AccessToken,
+			kind:   kindAccess,
+			now:    now,
+		},
+		{
+			name:   "valid refresh token",
+			signer: signer,
+			token:  token.RefreshToken,
+			kind:   kindRefresh,
+			now:    now.Add(AccessTokenTTL),
+		},
+		{
+			name:   "valid access token a second before expiry",
+			signer: signer,
+			token:  token.AccessToken,
+			kind:   kindAccess,
+			now:    now.Add(AccessTokenTTL - time.Second),
+		},
+		{
+			name:    "invalid by expired access token",
+			signer:  signer,
+			token:   token.AccessToken,
+			kind:    kindAccess,
+			now:     now.Add(AccessTokenTTL),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name:    "invalid by refresh token used as access token",
+			signer:  signer,
+			token:   token.RefreshToken,
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "invalid by other secret",
+			signer:  NewSigner("other secret"),
+			token:   token.AccessToken,
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "invalid by tampered payload",
+			signer:  signer,
+			token:   strings.Join(tampered, "."),
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "invalid by tampered signature",
+			signer:  signer,
+			token:   tamperedSignature,
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "invalid by alg none",
+			signer:  signer,
+			token:   noneToken,
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "invalid by other algorithm",
+			signer:  signer,
+			token:   otherAlgToken,
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "invalid by malformed token",
+			signer:  signer,
+			token:   "not a token",
+			kind:    kindAccess,
+			now:     now,
+			wantErr: ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.signer.parse(tt.token, tt.kind, tt.now)
+			if err != tt.wantErr {
+				t.Errorf("Signer.parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (c.Subject != user.ID || c.Email != user.Email) {
+				t.Errorf("Signer.parse() claims = %v, want subject %v", c, user.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "auth"),
+			errors:   errors.With("service", "auth"),
+			latency:  latency.With("service", "auth"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Login(ctx context.Context, credentials *domain.Credentials) (res *domain.Token, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Login", begin, err)
+	}(time.Now())
+	return mw.Service.Login(ctx, credentials)
+}
+func (mw instrumentingMiddleware) Refresh(ctx context.Context, refreshToken string) (res *domain.Token, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Refresh", begin, err)
+	}(time.Now())
+	return mw.Service.Refresh(ctx, refreshToken)
+}
+func (mw instrumentingMiddleware) Verify(ctx context.Context, accessToken string) (res *Principal, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Verify", begin, err)
+	}(time.Now())
+	return mw.Service.Verify(ctx, accessToken)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw validationMiddleware) Login(ctx context.Context, credentials *domain.Credentials) (*domain.Token, error) {
+	errs := validation.Errors{}
+	credentials.Email = strings.TrimSpace(credentials.Email)
+	if credentials.Email == "" {
+		errs.Add("email", validation.CodeRequired, ErrEmailIsRequired)
+	}
+	if credentials.Password == "" {
+		errs.Add("password", validation.CodeRequired, ErrPasswordIsRequired)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return mw.Service.Login(ctx, credentials)
+}
+func (mw validationMiddleware) Refresh(ctx context.Context, refreshToken string) (*domain.Token, error) {
+	if refreshToken == "" {
+		return nil, ErrRefreshTokenIsRequired
+	}
+	return mw.Service.Refresh(ctx, refreshToken)
+}
+func (mw validationMiddleware) Verify(ctx context.Context, accessToken string) (*Principal, error) {
+	if accessToken == "" {
+		return nil, ErrMissingToken
+	}
+	return mw.Service.Verify(ctx, accessToken)
+}
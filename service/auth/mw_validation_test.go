@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_Login(t *testing.T) {
+	var gotEmail string
+	serviceMock := &ServiceMock{
+		LoginFunc: func(_ context.Context, p *domain.Credentials) (*domain.Token, error) {
+			gotEmail = p.Email
+			return &domain.Token{}, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.Credentials
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantEmail       string
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name:      "valid credentials are trimmed",
+			args:      args{&domain.Credentials{Email: " example@gmail.com ", Password: "s3cret-password"}},
+			wantEmail: "example@gmail.com",
+		},
+		{
+			name:            "invalid credentials by missing email",
+			args:            args{&domain.Credentials{Email: "  ", Password: "s3cret-password"}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "invalid credentials by missing password",
+			args:            args{&domain.Credentials{Email: "example@gmail.com"}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			_, err := mw.Login(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Login() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Login() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Login() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Login() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotEmail != tt.wantEmail {
+				t.Errorf("validationMiddleware.Login() email = %v, want %v", gotEmail, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func Test_validationMiddleware_Verify(t *testing.T) {
+	serviceMock := &ServiceMock{
+		VerifyFunc: func(_ context.Context, accessToken string) (*Principal, error) {
+			return &Principal{}, nil
+		},
+	}
+
+	mw := validationMiddleware{
+		Service: serviceMock,
+	}
+	if _, err := mw.Verify(context.Background(), ""); err != ErrMissingToken {
+		t.Errorf("validationMiddleware.Verify() error = %v, wantErr %v", err, ErrMissingToken)
+	}
+	if _, err := mw.Verify(context.Background(), "token"); err != nil {
+		t.Errorf("validationMiddleware.Verify() error = %v, wantErr %v", err, nil)
+	}
+}
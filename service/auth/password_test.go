@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("s3cret-password")
+	if err != nil {
+		t.Fatalf("Failed to hash password by error %v", err)
+	}
+	other, err := HashPassword("s3cret-password")
+	if err != nil {
+		t.Fatalf("Failed to hash password by error %v", err)
+	}
+	if hash == other {
+		t.Errorf("HashPassword() = %v, want a different salt for each hash", hash)
+	}
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		want     bool
+	}{
+		{
+			name:     "matched password",
+			hash:     hash,
+			password: "s3cret-password",
+			want:     true,
+		},
+		{
+			name:     "wrong password",
+			hash:     hash,
+			password: "s3cret-passwore",
+		},
+		{
+			name:     "empty hash of user who has no password",
+			hash:     "",
+			password: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckPassword(tt.hash, tt.password); got != tt.want {
+				t.Errorf("CheckPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPassword_storedHash(t *testing.T) {
+	// key of test vector of PBKDF2-HMAC-SHA256, RFC 7914 section 11, in
+	// format of stored hashes
+	hash := "pbkdf2-sha256$1$c2FsdA$VawEblbjCJ/sFpHCJUS2BflBhSFt3gRl5oudV8INrLxJypzM8Xm2RZkWZLOdd+8xfHG4RbHjC9UJESBB06GXgw"
+	if !CheckPassword(hash, "passwd") {
+		t.Errorf("CheckPassword() = false, want true for stored hash %v", hash)
+	}
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// pgService implmenter for Auth serivce in postgres
+type pgService struct {
+	db     *gorm.DB
+	signer *Signer
+}
+
+// NewPGService create new PGService, tokens are signed by signer
+func NewPGService(db *gorm.DB, signer *Signer) Service {
+	return &pgService{
+		db:     db,
+		signer: signer,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// Login implement Login for Auth service, an unknown email and a wrong
+// password fail alike so emails of users are not disclosed. Email is unique
+// regardless of case, so it matches one user at most
+func (s *pgService) Login(ctx context.Context, p *domain.Credentials) (*domain.Token, error) {
+	s = s.withContext(ctx)
+	user := domain.User{}
+	err := s.db.Where("lower(email) = lower(?)", p.Email).First(&user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if !CheckPassword(user.PasswordHash, p.Password) {
+		return nil, ErrInvalidCredentials
+	}
+	return s.signer.issue(&user, time.Now())
+}
+
+// Refresh implement Refresh for Auth service, a deleted user can not refresh
+func (s *pgService) Refresh(ctx context.Context, refreshToken string) (*domain.Token, error) {
+	s = s.withContext(ctx)
+	now := time.Now()
+	c, err := s.signer.parse(refreshToken, kindRefresh, now)
+	if err != nil {
+		return nil, err
+	}
+	user := domain.User{Model: domain.Model{ID: c.Subject}}
+	if err := s.db.Find(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	return s.signer.issue(&user, now)
+}
+
+// Verify implement Verify for Auth service, access token is verified by
+// its signature only so no query is made
+func (s *pgService) Verify(_ context.Context, accessToken string) (*Principal, error) {
+	c, err := s.signer.parse(accessToken, kindAccess, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{UserID: c.Subject, Email: c.Email, Role: c.Role}, nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestPGService_Login(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	hash, err := HashPassword("s3cret-password")
+	if err != nil {
+		t.Fatalf("Failed to hash password by error %v", err)
+	}
+	user := domain.User{Email: "example@gmail.com", PasswordHash: hash}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	type args struct {
+		p *domain.Credentials
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success login",
+			args: args{&domain.Credentials{Email: "Example@gmail.com", Password: "s3cret-password"}},
+		},
+		{
+			name:    "failed login by wrong password",
+			args:    args{&domain.Credentials{Email: "example@gmail.com", Password: "wrong-password"}},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name:    "failed login by not exist email",
+			args:    args{&domain.Credentials{Email: "other@gmail.com", Password: "s3cret-password"}},
+			wantErr: ErrInvalidCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db:     testDB,
+				signer: NewSigner("secret"),
+			}
+			got, err := s.Login(context.Background(), tt.args.p)
+			if err != tt.wantErr {
+				t.Errorf("pgService.Login() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			principal, err := s.Verify(context.Background(), got.AccessToken)
+			if err != nil || principal.UserID != user.ID {
+				t.Errorf("pgService.Verify() = %v, error = %v, want user %v", principal, err, user.ID)
+			}
+		})
+	}
+}
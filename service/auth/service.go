@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Service interface for project service
+type Service interface {
+	Login(ctx context.Context, p *domain.Credentials) (*domain.Token, error)
+	Refresh(ctx context.Context, refreshToken string) (*domain.Token, error)
+	Verify(ctx context.Context, accessToken string) (*Principal, error)
+}
@@ -2,97 +2,75 @@ package book
 
 import (
 	"net/http"
+	"sort"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/apperror"
 )
 
 // Error Declaration
 var (
-	ErrNotFound                 = errNotFound{}
-	ErrUnknown                  = errUnknown{}
-	ErrNameIsRequired           = errNameIsRequired{}
-	ErrRecordNotFound           = errRecordNotFound{}
-	ErrNotExistCategoryID       = errNotExistCategoryID{}
-	ErrCategoryIDIsRequired     = errCategoryIDIsRequired{}
-	ErrMinimumLengthName        = errMinimumLengthName{}
-	ErrDescriptionIsRequired    = errDescriptionIsRequired{}
-	ErrMinimumLengthDescription = errMinimumLengthDescription{}
+	ErrNotFound                 = apperror.New("book.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown                  = apperror.New("book.unknown", http.StatusBadRequest, "unknown error")
+	ErrNameIsRequired           = apperror.New("book.name_is_required", http.StatusBadRequest, "book name is required")
+	ErrRecordNotFound           = apperror.New("book.record_not_found", http.StatusNotFound, "client record not found")
+	ErrNotExistCategoryID       = apperror.New("book.not_exist_category_id", http.StatusNotFound, "The ID for category not exist in table Categories")
+	ErrCategoryIDIsRequired     = apperror.New("book.category_id_is_required", http.StatusBadRequest, "Category id is required")
+	ErrMinimumLengthName        = apperror.New("book.minimum_length_name", http.StatusBadRequest, "Minimum Length for Name is 5 characters ")
+	ErrDescriptionIsRequired    = apperror.New("book.description_is_required", http.StatusBadRequest, "Description is required")
+	ErrMinimumLengthDescription = apperror.New("book.minimum_length_description", http.StatusBadRequest, "Minimum length for description is 5 characters")
+	ErrEmptyBatch               = apperror.New("book.empty_batch", http.StatusBadRequest, "Batch has no book")
+	ErrBatchTooLarge            = apperror.New("book.batch_too_large", http.StatusBadRequest, "Batch has too many books")
+	ErrInvalidBatchMode         = apperror.New("book.invalid_batch_mode", http.StatusBadRequest, "Mode of batch is invalid")
+	ErrInvalidTagMatch          = apperror.New("book.invalid_tag_match", http.StatusBadRequest, "Tag match must be any or all")
+	ErrSearchQueryIsRequired    = apperror.New("book.search_query_is_required", http.StatusBadRequest, "Search query is required")
 )
 
-type errNotFound struct{}
-
-func (errNotFound) Error() string {
-	return "record not found"
-}
-func (errNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errUnknown struct{}
-
-func (errUnknown) Error() string {
-	return "unknown error"
-}
-func (errUnknown) StatusCode() int {
-	return http.StatusBadRequest
+// BatchItemError describe error of a book in batch at Index
+type BatchItemError struct {
+	Index   int    `json:"index"`
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+	Message string `json:"error"`
 }
 
-type errRecordNotFound struct{}
-
-func (errRecordNotFound) Error() string {
-	return "client record not found"
+// BatchError is returned when some books of batch fail,
+// it lists the error of each failed book
+type BatchError struct {
+	Items []BatchItemError
 }
-func (errRecordNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errNameIsRequired struct{}
 
-func (errNameIsRequired) Error() string {
-	return "book name is required"
+func (e *BatchError) add(index int, book *domain.Book, err error) {
+	e.Items = append(e.Items, BatchItemError{
+		Index:   index,
+		Name:    book.Name,
+		Code:    apperror.CodeOf(err, apperror.StatusOf(err)),
+		Message: err.Error(),
+	})
 }
 
-func (errNameIsRequired) StatusCode() int {
-	return http.StatusBadRequest
+// merge add errors of a sub batch, indexes maps index in sub batch to index in batch
+func (e *BatchError) merge(sub BatchError, indexes []int) {
+	for _, item := range sub.Items {
+		item.Index = indexes[item.Index]
+		e.Items = append(e.Items, item)
+	}
+	sort.Slice(e.Items, func(i, j int) bool { return e.Items[i].Index < e.Items[j].Index })
 }
 
-type errNotExistCategoryID struct{}
-
-func (errNotExistCategoryID) Error() string {
-	return "The ID for category not exist in table Categories"
+func (BatchError) Error() string {
+	return "Some books of batch are invalid"
 }
-
-func (errNotExistCategoryID) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errCategoryIDIsRequired struct{}
-
-func (errCategoryIDIsRequired) Error() string {
-	return "Category id is required"
-}
-func (errCategoryIDIsRequired) StatusCode() int {
+func (BatchError) StatusCode() int {
 	return http.StatusBadRequest
 }
 
-type errMinimumLengthName struct{}
-
-func (errMinimumLengthName) Error() string {
-	return "Minimum Length for Name is 5 characters "
-}
-func (errMinimumLengthName) StatusCode() int {
-	return http.StatusBadRequest
+// Code return code of error
+func (BatchError) Code() string {
+	return "book.batch_failed"
 }
 
-type errDescriptionIsRequired struct{}
-
-func (errDescriptionIsRequired) Error() string {
-	return "Description is required"
-}
-func (errDescriptionIsRequired) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errMinimumLengthDescription struct{}
-
-func (errMinimumLengthDescription) Error() string {
-	return "Minimum length for description is 5 characters"
+// Details return errors of failed items
+func (e BatchError) Details() interface{} {
+	return e.Items
 }
@@ -0,0 +1,65 @@
+package book
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits any authenticated caller to read and search
+// books, only staff to change them
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, book *domain.Book) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, book)
+}
+func (mw authorizationMiddleware) CreateBatch(ctx context.Context, books []*domain.Book, mode BatchMode) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.CreateBatch(ctx, books, mode)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Book, *domain.ListMeta, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw authorizationMiddleware) Search(ctx context.Context, query string, limit int) ([]domain.BookSearchResult, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Search(ctx, query, limit)
+}
+func (mw authorizationMiddleware) Find(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Find(ctx, book)
+}
+func (mw authorizationMiddleware) Update(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Update(ctx, book)
+}
+func (mw authorizationMiddleware) Delete(ctx context.Context, book *domain.Book) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.Delete(ctx, book)
+}
@@ -0,0 +1,81 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "book"),
+			errors:   errors.With("service", "book"),
+			latency:  latency.With("service", "book"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Create(ctx context.Context, book *domain.Book) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Create", begin, err)
+	}(time.Now())
+	return mw.Service.Create(ctx, book)
+}
+func (mw instrumentingMiddleware) CreateBatch(ctx context.Context, books []*domain.Book, mode BatchMode) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("CreateBatch", begin, err)
+	}(time.Now())
+	return mw.Service.CreateBatch(ctx, books, mode)
+}
+func (mw instrumentingMiddleware) Update(ctx context.Context, book *domain.Book) (res *domain.Book, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Update", begin, err)
+	}(time.Now())
+	return mw.Service.Update(ctx, book)
+}
+func (mw instrumentingMiddleware) Find(ctx context.Context, book *domain.Book) (res *domain.Book, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Find", begin, err)
+	}(time.Now())
+	return mw.Service.Find(ctx, book)
+}
+func (mw instrumentingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.Book, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAll", begin, err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw instrumentingMiddleware) Search(ctx context.Context, query string, limit int) (res []domain.BookSearchResult, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Search", begin, err)
+	}(time.Now())
+	return mw.Service.Search(ctx, query, limit)
+}
+func (mw instrumentingMiddleware) Delete(ctx context.Context, book *domain.Book) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Delete", begin, err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, book)
+}
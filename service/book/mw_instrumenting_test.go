@@ -0,0 +1,53 @@
+package book
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_instrumentingMiddleware_Find(t *testing.T) {
+	serviceMock := &ServiceMock{
+		FindFunc: func(_ context.Context, p *domain.Book) (*domain.Book, error) {
+			if p.ID.IsZero() {
+				return nil, ErrNotFound
+			}
+			return p, nil
+		},
+	}
+
+	r := stdprometheus.NewRegistry()
+	fieldKeys := []string{"service", "method"}
+	requests := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "requests_total", Help: "requests"}, fieldKeys)
+	errors := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "errors_total", Help: "errors"}, fieldKeys)
+	latency := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{Name: "duration_seconds", Help: "latency", Buckets: []float64{1}}, fieldKeys)
+	r.MustRegister(requests, errors, latency)
+	mw := InstrumentingMiddleware(
+		kitprometheus.NewCounter(requests),
+		kitprometheus.NewCounter(errors),
+		kitprometheus.NewHistogram(latency),
+	)(serviceMock)
+	bookID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	mw.Find(context.Background(), &domain.Book{Model: domain.Model{ID: bookID}})
+	mw.Find(context.Background(), &domain.Book{})
+
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(r, promhttp.HandlerOpts{}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	got := rec.Body.String()
+	for _, want := range []string{
+		`requests_total{method="Find",service="book"} 2`,
+		`errors_total{method="Find",service="book"} 1`,
+		`duration_seconds_count{method="Find",service="book"} 2`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("instrumentingMiddleware.Find() metrics = %s, want it contains %s", got, want)
+		}
+	}
+}
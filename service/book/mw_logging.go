@@ -0,0 +1,68 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type loggingMiddleware struct {
+	Service
+	logger log.Logger
+}
+
+// LoggingMiddleware log every call of service with its duration and error
+func LoggingMiddleware(logger log.Logger) func(Service) Service {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			Service: next,
+			logger:  log.With(logger, "service", "book"),
+		}
+	}
+}
+
+func (mw loggingMiddleware) Create(ctx context.Context, book *domain.Book) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "book_id", book.ID, "category_id", book.CategoryID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Create(ctx, book)
+}
+func (mw loggingMiddleware) CreateBatch(ctx context.Context, books []*domain.Book, mode BatchMode) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "CreateBatch", "count", len(books), "mode", mode, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.CreateBatch(ctx, books, mode)
+}
+func (mw loggingMiddleware) Update(ctx context.Context, book *domain.Book) (res *domain.Book, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Update", "book_id", book.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Update(ctx, book)
+}
+func (mw loggingMiddleware) Find(ctx context.Context, book *domain.Book) (res *domain.Book, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "book_id", book.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Find(ctx, book)
+}
+func (mw loggingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.Book, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "FindAll", "count", len(res), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw loggingMiddleware) Search(ctx context.Context, query string, limit int) (res []domain.BookSearchResult, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Search", "count", len(res), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Search(ctx, query, limit)
+}
+func (mw loggingMiddleware) Delete(ctx context.Context, book *domain.Book) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "book_id", book.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, book)
+}
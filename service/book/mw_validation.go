@@ -2,11 +2,16 @@ package book
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
 )
 
-// Declare Regex
+// maxBatchSize is the greatest number of books created in one batch
+const maxBatchSize = 100
 
 type validationMiddleware struct {
 	Service
@@ -21,41 +26,136 @@ func ValidationMiddleware() func(Service) Service {
 	}
 }
 
-func (mw validationMiddleware) Create(ctx context.Context, book *domain.Book) (err error) {
+// validateCreate check book has what is needed to be created
+func validateCreate(book *domain.Book) error {
+	errs := validation.Errors{}
 	//check empty and length of name, description
 	if book.Name == "" {
-		return ErrNameIsRequired
-	}
-	if len(book.Name) <= 5 {
-		return ErrMinimumLengthName
+		errs.Add("name", validation.CodeRequired, ErrNameIsRequired)
+	} else if len(book.Name) <= 5 {
+		errs.Add("name", validation.CodeTooShort, ErrMinimumLengthName)
 	}
 	if book.Description == "" {
-		return ErrDescriptionIsRequired
-	}
-	if len(book.Description) <= 5 {
-		return ErrMinimumLengthDescription
+		errs.Add("description", validation.CodeRequired, ErrDescriptionIsRequired)
+	} else if len(book.Description) <= 5 {
+		errs.Add("description", validation.CodeTooShort, ErrMinimumLengthDescription)
 	}
 	//check empty of category_id
 	if book.CategoryID.IsZero() {
-		return ErrCategoryIDIsRequired
+		errs.Add("category_id", validation.CodeRequired, ErrCategoryIDIsRequired)
+	}
+	return errs.Err()
+}
+
+func (mw validationMiddleware) Create(ctx context.Context, book *domain.Book) (err error) {
+	if err := validateCreate(book); err != nil {
+		return err
 	}
 	return mw.Service.Create(ctx, book)
 }
-func (mw validationMiddleware) FindAll(ctx context.Context) ([]domain.Book, error) {
-	return mw.Service.FindAll(ctx)
+func (mw validationMiddleware) CreateBatch(ctx context.Context, books []*domain.Book, mode BatchMode) (err error) {
+	if len(books) == 0 {
+		return ErrEmptyBatch
+	}
+	if len(books) > maxBatchSize {
+		return ErrBatchTooLarge
+	}
+	if mode == "" {
+		mode = BatchModeAllOrNothing
+	}
+	if mode != BatchModeAllOrNothing && mode != BatchModeBestEffort {
+		return ErrInvalidBatchMode
+	}
+
+	var (
+		batchErr = BatchError{}
+		valid    = []*domain.Book{}
+		indexes  = []int{}
+	)
+	for i, book := range books {
+		if err := validateCreate(book); err != nil {
+			batchErr.add(i, book, err)
+			continue
+		}
+		valid = append(valid, book)
+		indexes = append(indexes, i)
+	}
+	if len(batchErr.Items) > 0 && (mode == BatchModeAllOrNothing || len(valid) == 0) {
+		return batchErr
+	}
+
+	// in best effort mode valid books are created, errors of both are reported
+	err = mw.Service.CreateBatch(ctx, valid, mode)
+	if subErr, ok := err.(BatchError); ok {
+		batchErr.merge(subErr, indexes)
+	} else if err != nil {
+		return err
+	}
+	if len(batchErr.Items) > 0 {
+		return batchErr
+	}
+	return nil
+}
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Book, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "name", "author", "category_id", "available", "tag", "match"); err != nil {
+		return nil, nil, err
+	}
+	if _, err := list.FilterUUID(q, "category_id"); err != nil {
+		return nil, nil, err
+	}
+	if available := q.Filter("available"); available != "" {
+		if _, err := strconv.ParseBool(available); err != nil {
+			return nil, nil, list.ErrInvalidFilter
+		}
+	}
+
+	// an empty tag in query is ignored
+	names := []string{}
+	for _, tag := range domain.NormalizeTagNames(q.Filters["tag"]) {
+		if tag != "" {
+			names = append(names, tag)
+		}
+	}
+	if len(names) > 0 {
+		q.Filters["tag"] = names
+	} else {
+		delete(q.Filters, "tag")
+	}
+	match := TagMatch(q.Filter("match"))
+	if match != "" && match != TagMatchAny && match != TagMatchAll {
+		return nil, nil, ErrInvalidTagMatch
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw validationMiddleware) Search(ctx context.Context, query string, limit int) ([]domain.BookSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrSearchQueryIsRequired
+	}
+	if limit < 0 || limit > list.MaxLimit {
+		return nil, list.ErrInvalidLimit
+	}
+	if limit == 0 {
+		limit = list.DefaultLimit
+	}
+	return mw.Service.Search(ctx, query, limit)
 }
 func (mw validationMiddleware) Find(ctx context.Context, book *domain.Book) (*domain.Book, error) {
 	return mw.Service.Find(ctx, book)
 }
 
 func (mw validationMiddleware) Update(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	errs := validation.Errors{}
 	//check length of name
 	if book.Name != "" && len(book.Name) <= 5 {
-		return nil, ErrMinimumLengthName
+		errs.Add("name", validation.CodeTooShort, ErrMinimumLengthName)
 	}
 	//check length of description
 	if book.Description != "" && len(book.Description) <= 5 {
-		return nil, ErrMinimumLengthDescription
+		errs.Add("description", validation.CodeTooShort, ErrMinimumLengthDescription)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
 	}
 	return mw.Service.Update(ctx, book)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/validation"
 )
 
 func Test_validationMiddleware_Update(t *testing.T) {
@@ -219,52 +220,255 @@ func Test_validationMiddleware_Create(t *testing.T) {
 	}
 }
 
-func Test_validationMiddleware_Find(t *testing.T) {
-	type fields struct {
-		Service Service
+func Test_validationMiddleware_CreateAllErrors(t *testing.T) {
+	mw := validationMiddleware{
+		Service: &ServiceMock{},
+	}
+	err := mw.Create(context.Background(), &domain.Book{Name: "Go", Description: ""})
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		t.Fatalf("validationMiddleware.Create() error = %v, want validation.Errors", err)
+	}
+	want := []validation.FieldError{
+		{Field: "name", Code: validation.CodeTooShort, Message: ErrMinimumLengthName.Error()},
+		{Field: "description", Code: validation.CodeRequired, Message: ErrDescriptionIsRequired.Error()},
+		{Field: "category_id", Code: validation.CodeRequired, Message: ErrCategoryIDIsRequired.Error()},
+	}
+	if !reflect.DeepEqual(errs.Items, want) {
+		t.Errorf("validationMiddleware.Create() errors = %v, want %v", errs.Items, want)
+	}
+}
+
+func Test_validationMiddleware_CreateBatch(t *testing.T) {
+	categoryID := domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")
+	newBook := func(name string) *domain.Book {
+		return &domain.Book{
+			Name:        name,
+			CategoryID:  categoryID,
+			Description: "the book is very bad",
+		}
+	}
+	// service fails the first book it is given
+	serviceMock := &ServiceMock{
+		CreateBatchFunc: func(_ context.Context, p []*domain.Book, mode BatchMode) error {
+			batchErr := BatchError{}
+			batchErr.add(0, p[0], ErrNotExistCategoryID)
+			return batchErr
+		},
 	}
+
+	defaultCtx := context.Background()
 	type args struct {
-		ctx context.Context
-		p   *domain.Book
+		p    []*domain.Book
+		mode BatchMode
 	}
 	tests := []struct {
-		name       string
-		fields     fields
-		args       args
-		wantOutput *domain.Book
-		wantErr    bool
+		name           string
+		args           args
+		wantErr        bool
+		wantItemErrors []int
+		wantItemCodes  []string
 	}{
-		// TODO: Add test cases.
+		{
+			name:    "invalid batch by no book",
+			args:    args{[]*domain.Book{}, BatchModeAllOrNothing},
+			wantErr: true,
+		},
+		{
+			name:    "invalid batch by unknown mode",
+			args:    args{[]*domain.Book{newBook("why not love me.")}, "sometimes"},
+			wantErr: true,
+		},
+		{
+			name: "invalid batch by invalid book in all or nothing mode",
+			args: args{[]*domain.Book{
+				newBook("why not love me."),
+				newBook("short"),
+			}, BatchModeAllOrNothing},
+			wantErr:        true,
+			wantItemErrors: []int{1},
+			wantItemCodes:  []string{"validation_failed"},
+		},
+		{
+			name: "errors of validation and service in best effort mode",
+			args: args{[]*domain.Book{
+				newBook("short"),
+				newBook("why not love me."),
+				newBook("why not love you."),
+			}, BatchModeBestEffort},
+			wantErr:        true,
+			wantItemErrors: []int{0, 1},
+			wantItemCodes:  []string{"validation_failed", "book.not_exist_category_id"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mw := validationMiddleware{
-				Service: tt.fields.Service,
+				Service: serviceMock,
 			}
-			gotOutput, err := mw.Find(tt.args.ctx, tt.args.p)
+			err := mw.CreateBatch(defaultCtx, tt.args.p, tt.args.mode)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validationMiddleware.Find() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("validationMiddleware.CreateBatch() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(gotOutput, tt.wantOutput) {
-				t.Errorf("validationMiddleware.Find() = %v, want %v", gotOutput, tt.wantOutput)
+			if tt.wantItemErrors == nil {
+				return
+			}
+
+			batchErr, ok := err.(BatchError)
+			if !ok {
+				t.Errorf("validationMiddleware.CreateBatch() error %v is not BatchError", err)
+				return
+			}
+			gotItemErrors := []int{}
+			gotItemCodes := []string{}
+			for _, item := range batchErr.Items {
+				gotItemErrors = append(gotItemErrors, item.Index)
+				gotItemCodes = append(gotItemCodes, item.Code)
+			}
+			if !reflect.DeepEqual(gotItemErrors, tt.wantItemErrors) {
+				t.Errorf("validationMiddleware.CreateBatch() failed items = %v, want %v", gotItemErrors, tt.wantItemErrors)
+			}
+			if !reflect.DeepEqual(gotItemCodes, tt.wantItemCodes) {
+				t.Errorf("validationMiddleware.CreateBatch() codes of failed items = %v, want %v", gotItemCodes, tt.wantItemCodes)
 			}
 		})
 	}
 }
 
 func Test_validationMiddleware_FindAll(t *testing.T) {
+	serviceMock := &ServiceMock{
+		FindAllFunc: func(_ context.Context, q *domain.ListQuery) ([]domain.Book, *domain.ListMeta, error) {
+			return []domain.Book{}, &domain.ListMeta{}, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	tests := []struct {
+		name    string
+		args    domain.ListQuery
+		wantErr bool
+	}{
+		{
+			name: "valid query with filters and sort",
+			args: domain.ListQuery{
+				Filters: map[string][]string{"name": {"go"}, "author": {"pike"}, "available": {"true"}},
+				Sort:    []domain.SortField{{Field: "name", Desc: true}},
+				Limit:   10,
+			},
+		},
+		{
+			name: "valid tags with default match",
+			args: domain.ListQuery{Filters: map[string][]string{"tag": {"Science", ""}}},
+		},
+		{
+			name: "valid tags with all match",
+			args: domain.ListQuery{Filters: map[string][]string{"tag": {"science", "fiction"}, "match": {"all"}}},
+		},
+		{
+			name: "valid query by empty tag names",
+			args: domain.ListQuery{Filters: map[string][]string{"tag": {" ", ""}}},
+		},
+		{
+			name:    "invalid tags by unknown match",
+			args:    domain.ListQuery{Filters: map[string][]string{"tag": {"science"}, "match": {"most"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid query by unknown filter",
+			args:    domain.ListQuery{Filters: map[string][]string{"isbn": {"123"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid query by available",
+			args:    domain.ListQuery{Filters: map[string][]string{"available": {"maybe"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid query by category id",
+			args:    domain.ListQuery{Filters: map[string][]string{"category_id": {"abc"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid query by sort field",
+			args:    domain.ListQuery{Sort: []domain.SortField{{Field: "description"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid query by limit",
+			args:    domain.ListQuery{Limit: 1000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			if _, _, err := mw.FindAll(defaultCtx, &tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("validationMiddleware.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validationMiddleware_Search(t *testing.T) {
+	serviceMock := &ServiceMock{
+		SearchFunc: func(_ context.Context, query string, limit int) ([]domain.BookSearchResult, error) {
+			return []domain.BookSearchResult{}, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		query string
+		limit int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid search with default limit",
+			args: args{"golang concurrency", 0},
+		},
+		{
+			name:    "invalid search by empty query",
+			args:    args{"  ", 10},
+			wantErr: true,
+		},
+		{
+			name:    "invalid search by limit",
+			args:    args{"golang", 1000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			if _, err := mw.Search(defaultCtx, tt.args.query, tt.args.limit); (err != nil) != tt.wantErr {
+				t.Errorf("validationMiddleware.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validationMiddleware_Find(t *testing.T) {
 	type fields struct {
 		Service Service
 	}
 	type args struct {
 		ctx context.Context
+		p   *domain.Book
 	}
 	tests := []struct {
 		name       string
 		fields     fields
 		args       args
-		wantOutput []domain.Book
+		wantOutput *domain.Book
 		wantErr    bool
 	}{
 		// TODO: Add test cases.
@@ -274,13 +478,13 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 			mw := validationMiddleware{
 				Service: tt.fields.Service,
 			}
-			gotOutput, err := mw.FindAll(tt.args.ctx)
+			gotOutput, err := mw.Find(tt.args.ctx, tt.args.p)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validationMiddleware.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("validationMiddleware.Find() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(gotOutput, tt.wantOutput) {
-				t.Errorf("validationMiddleware.FindAll() = %v, want %v", gotOutput, tt.wantOutput)
+				t.Errorf("validationMiddleware.Find() = %v, want %v", gotOutput, tt.wantOutput)
 			}
 		})
 	}
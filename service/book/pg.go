@@ -2,10 +2,14 @@ package book
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/jinzhu/gorm"
 
+	"github.com/phungvandat/example-go/config/database/pg"
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
 )
 
 // pgService implmenter for Book serivce in postgres
@@ -20,8 +24,110 @@ func NewPGService(db *gorm.DB) Service {
 	}
 }
 
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// copyCount is number of copies of a book
+type copyCount struct {
+	BookID    domain.UUID
+	Total     int
+	Available int
+}
+
+// copyIsAvailable is condition of an available book copy c
+const copyIsAvailable = `NOT EXISTS (
+		SELECT 1 FROM lend_books l
+		WHERE l.book_copy_id = c.id AND l.returned_at IS NULL AND l.deleted_at IS NULL
+		AND l."from" <= now()
+	) AND NOT EXISTS (
+		SELECT 1 FROM holds h
+		WHERE h.book_copy_id = c.id AND h.status = 'ready' AND h.deleted_at IS NULL
+		AND h.pickup_deadline > now()
+	)`
+
+// fillCopyCounts load total and available copies of books, a copy is available
+// when it has no lend book which is started and not returned and it is not kept
+// for a ready hold, future lend books do not hold the copy yet
+func (s *pgService) fillCopyCounts(books ...*domain.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	ids := make([]domain.UUID, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	counts := []copyCount{}
+	err := s.db.Raw(`SELECT c.book_id, COUNT(*) AS total,
+		COUNT(*) FILTER (WHERE `+copyIsAvailable+`) AS available
+		FROM book_copies c
+		WHERE c.deleted_at IS NULL AND c.book_id IN (?)
+		GROUP BY c.book_id`, ids).Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	byBook := make(map[domain.UUID]copyCount, len(counts))
+	for _, c := range counts {
+		byBook[c.BookID] = c
+	}
+	for _, b := range books {
+		b.TotalCopies = byBook[b.ID].Total
+		b.AvailableCopies = byBook[b.ID].Available
+	}
+	return nil
+}
+
+// fillTags load names of tags of books
+func (s *pgService) fillTags(books ...*domain.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	ids := make([]domain.UUID, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	bookTags := []struct {
+		BookID domain.UUID
+		Name   string
+	}{}
+	err := s.db.Raw(`SELECT bt.book_id, t.name FROM book_tags bt
+		JOIN tags t ON t.id = bt.tag_id
+		WHERE bt.book_id IN (?)
+		ORDER BY t.name`, ids).Scan(&bookTags).Error
+	if err != nil {
+		return err
+	}
+
+	byBook := make(map[domain.UUID][]string, len(books))
+	for _, bt := range bookTags {
+		byBook[bt.BookID] = append(byBook[bt.BookID], bt.Name)
+	}
+	for _, b := range books {
+		b.Tags = byBook[b.ID]
+		if b.Tags == nil {
+			b.Tags = []string{}
+		}
+	}
+	return nil
+}
+
+// fillDetails load copy counts and tags of books
+func (s *pgService) fillDetails(books ...*domain.Book) error {
+	if err := s.fillCopyCounts(books...); err != nil {
+		return err
+	}
+	return s.fillTags(books...)
+}
+
 // Create implement Create for Book service
-func (s *pgService) Create(_ context.Context, p *domain.Book) error {
+func (s *pgService) Create(ctx context.Context, p *domain.Book) error {
+	s = s.withContext(ctx)
 	// Check id of category exist in table categories
 	var checkErr = s.db.Where("id = ?", p.CategoryID).Find(&domain.Category{}).Error
 	if checkErr != nil {
@@ -33,8 +139,84 @@ func (s *pgService) Create(_ context.Context, p *domain.Book) error {
 	return s.db.Create(p).Error
 }
 
+// CreateBatch implement CreateBatch for Book service, categories of all books
+// are checked by one query. In all or nothing mode no book is created when one
+// fails, in best effort mode the others are still created: each book runs in a
+// savepoint, a failed statement would abort the transaction otherwise.
+func (s *pgService) CreateBatch(ctx context.Context, p []*domain.Book, mode BatchMode) error {
+	s = s.withContext(ctx)
+	categoryIDs := []domain.UUID{}
+	for _, book := range p {
+		categoryIDs = append(categoryIDs, book.CategoryID)
+	}
+	existIDs := []domain.UUID{}
+	err := s.db.Model(&domain.Category{}).
+		Where("id IN (?)", categoryIDs).
+		Pluck("id", &existIDs).Error
+	if err != nil {
+		return err
+	}
+	existCategories := make(map[domain.UUID]bool, len(existIDs))
+	for _, id := range existIDs {
+		existCategories[id] = true
+	}
+
+	batchErr := BatchError{}
+	for i, book := range p {
+		if !existCategories[book.CategoryID] {
+			batchErr.add(i, book, ErrNotExistCategoryID)
+		}
+	}
+	if len(batchErr.Items) > 0 && mode == BatchModeAllOrNothing {
+		return batchErr
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for i, book := range p {
+		if !existCategories[book.CategoryID] {
+			continue
+		}
+		if mode == BatchModeAllOrNothing {
+			if err := tx.Create(book).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+			continue
+		}
+
+		// a failed book is rolled back alone, so the next ones are still created
+		if err := tx.Exec("SAVEPOINT book_batch_item").Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		err := tx.Create(book).Error
+		if err == nil {
+			continue
+		}
+		if err := tx.Exec("ROLLBACK TO SAVEPOINT book_batch_item").Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		// book is not stored, so it keeps no ID
+		book.Model = domain.Model{}
+		batchErr.add(i, book, err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	if len(batchErr.Items) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
 // Update implement Update for Book service
-func (s *pgService) Update(_ context.Context, p *domain.Book) (*domain.Book, error) {
+func (s *pgService) Update(ctx context.Context, p *domain.Book) (*domain.Book, error) {
+	s = s.withContext(ctx)
 	old := domain.Book{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -64,11 +246,18 @@ func (s *pgService) Update(_ context.Context, p *domain.Book) (*domain.Book, err
 		old.Description = p.Description
 	}
 
-	return &old, s.db.Save(&old).Error
+	if err := s.db.Save(&old).Error; err != nil {
+		return nil, err
+	}
+	if err := s.fillDetails(&old); err != nil {
+		return nil, err
+	}
+	return &old, nil
 }
 
 // Find implement Find for Book service
-func (s *pgService) Find(_ context.Context, p *domain.Book) (*domain.Book, error) {
+func (s *pgService) Find(ctx context.Context, p *domain.Book) (*domain.Book, error) {
+	s = s.withContext(ctx)
 	res := p
 	if err := s.db.Find(&res).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -77,17 +266,98 @@ func (s *pgService) Find(_ context.Context, p *domain.Book) (*domain.Book, error
 		return nil, err
 	}
 
+	if err := s.fillDetails(res); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
-// FindAll implement FindAll for Book service
-func (s *pgService) FindAll(_ context.Context) ([]domain.Book, error) {
+// FindAll implement FindAll for Book service, books are filtered by name and
+// author substrings, category, availability of a copy and tags
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Book, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	if name := q.Filter("name"); name != "" {
+		db = db.Where("name ILIKE ?", list.Contains(name))
+	}
+	if author := q.Filter("author"); author != "" {
+		db = db.Where("author ILIKE ?", list.Contains(author))
+	}
+	categoryID, err := list.FilterUUID(q, "category_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !categoryID.IsZero() {
+		db = db.Where("category_id = ?", categoryID)
+	}
+	if available := q.Filter("available"); available != "" {
+		ok, err := strconv.ParseBool(available)
+		if err != nil {
+			return nil, nil, list.ErrInvalidFilter
+		}
+		cond := `EXISTS (SELECT 1 FROM book_copies c
+			WHERE c.book_id = books.id AND c.deleted_at IS NULL AND ` + copyIsAvailable + `)`
+		if !ok {
+			cond = "NOT " + cond
+		}
+		db = db.Where(cond)
+	}
+	if tags := q.Filters["tag"]; len(tags) > 0 {
+		switch TagMatch(q.Filter("match")) {
+		case TagMatchAll:
+			db = db.Where(`(SELECT COUNT(DISTINCT t.name) FROM book_tags bt JOIN tags t ON t.id = bt.tag_id
+				WHERE bt.book_id = books.id AND t.name IN (?)) = ?`, tags, len(tags))
+		default:
+			db = db.Where(`EXISTS (SELECT 1 FROM book_tags bt JOIN tags t ON t.id = bt.tag_id
+				WHERE bt.book_id = books.id AND t.name IN (?))`, tags)
+		}
+	}
+
 	res := []domain.Book{}
-	return res, s.db.Find(&res).Error
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	books := make([]*domain.Book, len(res))
+	for i := range res {
+		books[i] = &res[i]
+	}
+	if err := s.fillDetails(books...); err != nil {
+		return nil, nil, err
+	}
+	return res, meta, nil
+}
+
+// Search implement Search for Book service, books matching query are ranked by
+// relevance and the snippet marks matched terms with <b></b>
+func (s *pgService) Search(ctx context.Context, query string, limit int) ([]domain.BookSearchResult, error) {
+	s = s.withContext(ctx)
+	res := []domain.BookSearchResult{}
+	err := s.db.Raw(`SELECT books.*, ts_rank(books.search_vector, q) AS rank,
+		ts_headline('english', concat_ws(' ', books.name, books.author, books.description), q,
+			'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM books, websearch_to_tsquery('english', ?) q
+		WHERE books.deleted_at IS NULL AND books.search_vector @@ q
+		ORDER BY rank DESC, books.id
+		LIMIT ?`, query, limit).Scan(&res).Error
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]*domain.Book, len(res))
+	for i := range res {
+		books[i] = &res[i].Book
+	}
+	if err := s.fillDetails(books...); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // Delete implement Delete for Book service
-func (s *pgService) Delete(_ context.Context, p *domain.Book) error {
+func (s *pgService) Delete(ctx context.Context, p *domain.Book) error {
+	s = s.withContext(ctx)
 	old := domain.Book{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
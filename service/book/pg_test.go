@@ -5,8 +5,6 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/jinzhu/gorm"
-
 	testutil "github.com/phungvandat/example-go/config/database/pg/util"
 	"github.com/phungvandat/example-go/domain"
 )
@@ -58,6 +56,275 @@ func TestPGService_Create(t *testing.T) {
 	}
 }
 
+func TestPGService_CreateBatch(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	category := domain.Category{}
+	err = testDB.Create(&category).Error
+	if err != nil {
+		t.Fatalf("Failed to create category by error %v", err)
+	}
+
+	fakeCategoryID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	newBatch := func() []*domain.Book {
+		return []*domain.Book{
+			{Name: "why not love me.", CategoryID: category.ID, Description: "the book is very bad"},
+			{Name: "why not love you.", CategoryID: fakeCategoryID, Description: "the book is very bad"},
+			// text with NUL is rejected by database, not by checks of service
+			{Name: "why not love \x00", CategoryID: category.ID, Description: "the book is very bad"},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mode        BatchMode
+		wantBooks   int
+		wantIndexes []int
+	}{
+		{
+			name:        "nothing created in all or nothing mode",
+			mode:        BatchModeAllOrNothing,
+			wantBooks:   0,
+			wantIndexes: []int{1},
+		},
+		{
+			name:        "valid book created in best effort mode",
+			mode:        BatchModeBestEffort,
+			wantBooks:   1,
+			wantIndexes: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			err := s.CreateBatch(context.Background(), newBatch(), tt.mode)
+			batchErr, ok := err.(BatchError)
+			if !ok {
+				t.Errorf("pgService.CreateBatch() error = %v, want BatchError", err)
+				return
+			}
+			gotIndexes := []int{}
+			for _, item := range batchErr.Items {
+				gotIndexes = append(gotIndexes, item.Index)
+			}
+			if !reflect.DeepEqual(gotIndexes, tt.wantIndexes) {
+				t.Errorf("pgService.CreateBatch() failed indexes = %v, want %v", gotIndexes, tt.wantIndexes)
+				return
+			}
+
+			var count int
+			if err := testDB.Model(&domain.Book{}).Count(&count).Error; err != nil {
+				t.Fatalf("Failed to count book by error %v", err)
+			}
+			if count != tt.wantBooks {
+				t.Errorf("pgService.CreateBatch() created %v books, want %v", count, tt.wantBooks)
+			}
+		})
+	}
+}
+
+func TestPGService_FindAll(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	science := domain.Tag{Name: "science"}
+	fiction := domain.Tag{Name: "fiction"}
+	for _, tag := range []*domain.Tag{&science, &fiction} {
+		if err := testDB.Create(tag).Error; err != nil {
+			t.Fatalf("Failed to create tag by error %v", err)
+		}
+	}
+
+	// the first book has both tags and a copy, the second one has science only
+	books := []domain.Book{
+		{Name: "Cosmos", Author: "Carl Sagan"},
+		{Name: "Contact", Author: "Carl Sagan"},
+		{Name: "Dune", Author: "Frank Herbert"},
+	}
+	for i := range books {
+		if err := testDB.Create(&books[i]).Error; err != nil {
+			t.Fatalf("Failed to create book by error %v", err)
+		}
+	}
+	bookTags := []domain.BookTag{
+		{BookID: books[0].ID, TagID: science.ID},
+		{BookID: books[0].ID, TagID: fiction.ID},
+		{BookID: books[1].ID, TagID: science.ID},
+	}
+	for _, bookTag := range bookTags {
+		if err := testDB.Create(&bookTag).Error; err != nil {
+			t.Fatalf("Failed to create bookTag by error %v", err)
+		}
+	}
+	bookCopy := domain.BookCopy{BookID: books[0].ID, Barcode: "B-0001", Condition: domain.BookCopyConditionGood}
+	if err := testDB.Create(&bookCopy).Error; err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		filters   map[string][]string
+		wantBooks int
+	}{
+		{
+			name:      "all books",
+			wantBooks: 3,
+		},
+		{
+			name:      "books by name substring",
+			filters:   map[string][]string{"name": {"co"}},
+			wantBooks: 2,
+		},
+		{
+			name:      "books by author substring",
+			filters:   map[string][]string{"author": {"herbert"}},
+			wantBooks: 1,
+		},
+		{
+			name:      "available books",
+			filters:   map[string][]string{"available": {"true"}},
+			wantBooks: 1,
+		},
+		{
+			name:      "unavailable books",
+			filters:   map[string][]string{"available": {"false"}},
+			wantBooks: 2,
+		},
+		{
+			name:      "books having any tag",
+			filters:   map[string][]string{"tag": {"science", "fiction"}},
+			wantBooks: 2,
+		},
+		{
+			name:      "books having all tags",
+			filters:   map[string][]string{"tag": {"science", "fiction"}, "match": {"all"}},
+			wantBooks: 1,
+		},
+		{
+			name:      "no book having unknown tag",
+			filters:   map[string][]string{"tag": {"history"}},
+			wantBooks: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			got, meta, err := s.FindAll(context.Background(), &domain.ListQuery{Filters: tt.filters})
+			if err != nil {
+				t.Errorf("pgService.FindAll() error = %v", err)
+				return
+			}
+			if len(got) != tt.wantBooks || meta.Total != tt.wantBooks {
+				t.Errorf("pgService.FindAll() = %v books of %v, want %v", len(got), meta.Total, tt.wantBooks)
+			}
+		})
+	}
+
+	t.Run("books by pages", func(t *testing.T) {
+		s := &pgService{
+			db: testDB,
+		}
+		q := &domain.ListQuery{Sort: []domain.SortField{{Field: "name", Desc: true}}, Limit: 2}
+		names := []string{}
+		for {
+			got, meta, err := s.FindAll(context.Background(), q)
+			if err != nil {
+				t.Fatalf("pgService.FindAll() error = %v", err)
+			}
+			for _, book := range got {
+				names = append(names, book.Name)
+			}
+			if meta.NextCursor == "" {
+				break
+			}
+			q.Cursor = meta.NextCursor
+		}
+		if want := []string{"Dune", "Cosmos", "Contact"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("pgService.FindAll() pages = %v, want %v", names, want)
+		}
+	})
+}
+
+func TestPGService_Search(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	books := []domain.Book{
+		{Name: "The Go Programming Language", Author: "Alan Donovan", Description: "Concurrency with goroutines and channels"},
+		{Name: "Concurrency in Practice", Author: "Brian Goetz", Description: "Threads of java"},
+		{Name: "Dune", Author: "Frank Herbert", Description: "Desert planet"},
+	}
+	for i := range books {
+		if err := testDB.Create(&books[i]).Error; err != nil {
+			t.Fatalf("Failed to create book by error %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantNames []string
+	}{
+		{
+			name:      "match in name ranks first",
+			query:     "concurrency",
+			wantNames: []string{"Concurrency in Practice", "The Go Programming Language"},
+		},
+		{
+			name:      "match by author",
+			query:     "herbert",
+			wantNames: []string{"Dune"},
+		},
+		{
+			name:      "no match",
+			query:     "cooking",
+			wantNames: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			got, err := s.Search(context.Background(), tt.query, 10)
+			if err != nil {
+				t.Errorf("pgService.Search() error = %v", err)
+				return
+			}
+			names := []string{}
+			for _, book := range got {
+				names = append(names, book.Name)
+				if book.Snippet == "" {
+					t.Errorf("pgService.Search() snippet of %v is empty", book.Name)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("pgService.Search() = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
 func TestPGService_Update(t *testing.T) {
 	t.Parallel()
 	testDB, _, cleanup := testutil.CreateTestDatabase(t)
@@ -202,39 +469,6 @@ func TestPGService_Find(t *testing.T) {
 	}
 }
 
-func TestPGService_FindAll(t *testing.T) {
-	type fields struct {
-		db *gorm.DB
-	}
-	type args struct {
-		in0 context.Context
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []domain.Book
-		wantErr bool
-	}{
-		// TODO: Add test cases.
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &pgService{
-				db: tt.fields.db,
-			}
-			got, err := s.FindAll(tt.args.in0)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("pgService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("pgService.FindAll() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestPGService_Delete(t *testing.T) {
 	t.Parallel()
 	testDB, _, cleanup := testutil.CreateTestDatabase(t)
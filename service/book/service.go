@@ -4,13 +4,48 @@ import (
 	"context"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// sorting is fields which list of books can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+		"author":     "author",
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
+// BatchMode describe how a batch of books is created
+type BatchMode string
+
+// List of batch mode
+const (
+	// BatchModeAllOrNothing create no book when a book of batch fails
+	BatchModeAllOrNothing BatchMode = "all_or_nothing"
+	// BatchModeBestEffort create books of batch which do not fail
+	BatchModeBestEffort BatchMode = "best_effort"
+)
+
+// TagMatch describe how books are matched by tags
+type TagMatch string
+
+// List of tag match
+const (
+	// TagMatchAny match books having any of tags
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll match books having all of tags
+	TagMatchAll TagMatch = "all"
 )
 
 // Service interface for project service
 type Service interface {
 	Create(ctx context.Context, p *domain.Book) error
+	CreateBatch(ctx context.Context, p []*domain.Book, mode BatchMode) error
 	Update(ctx context.Context, p *domain.Book) (*domain.Book, error)
 	Find(ctx context.Context, p *domain.Book) (*domain.Book, error)
-	FindAll(ctx context.Context) ([]domain.Book, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Book, *domain.ListMeta, error)
+	Search(ctx context.Context, query string, limit int) ([]domain.BookSearchResult, error)
 	Delete(ctx context.Context, p *domain.Book) error
 }
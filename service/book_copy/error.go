@@ -0,0 +1,21 @@
+package book_copy
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrNotFound            = apperror.New("book_copy.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown             = apperror.New("book_copy.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound      = apperror.New("book_copy.record_not_found", http.StatusNotFound, "client record not found")
+	ErrBookIDIsRequired    = apperror.New("book_copy.book_id_is_required", http.StatusBadRequest, "ID of book is required")
+	ErrBookIDNotExist      = apperror.New("book_copy.book_id_not_exist", http.StatusNotFound, "ID of book not exist in table books")
+	ErrBarcodeIsRequired   = apperror.New("book_copy.barcode_is_required", http.StatusBadRequest, "Barcode is required")
+	ErrExistBarcode        = apperror.New("book_copy.exist_barcode", http.StatusBadRequest, "Barcode is exist in database")
+	ErrConditionIsInvalid  = apperror.New("book_copy.condition_is_invalid", http.StatusBadRequest, "Condition must be one of new, good, fair, poor, damaged")
+	ErrAcquiredAtIsInvalid = apperror.New("book_copy.acquired_at_is_invalid", http.StatusBadRequest, "Acquisition date can not be in the future")
+	ErrLendedBookCopy      = apperror.New("book_copy.lended_book_copy", http.StatusBadRequest, "The book copy is lended")
+)
@@ -0,0 +1,52 @@
+package book_copy
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits any authenticated caller to read book copies, only staff to change them
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, bookCopy *domain.BookCopy) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, bookCopy)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.BookCopy, *domain.ListMeta, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw authorizationMiddleware) Find(ctx context.Context, bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Find(ctx, bookCopy)
+}
+func (mw authorizationMiddleware) Update(ctx context.Context, bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Update(ctx, bookCopy)
+}
+func (mw authorizationMiddleware) Delete(ctx context.Context, bookCopy *domain.BookCopy) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.Delete(ctx, bookCopy)
+}
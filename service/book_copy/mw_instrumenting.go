@@ -0,0 +1,69 @@
+package book_copy
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "book_copy"),
+			errors:   errors.With("service", "book_copy"),
+			latency:  latency.With("service", "book_copy"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Create(ctx context.Context, bookCopy *domain.BookCopy) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Create", begin, err)
+	}(time.Now())
+	return mw.Service.Create(ctx, bookCopy)
+}
+func (mw instrumentingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.BookCopy, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAll", begin, err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw instrumentingMiddleware) Find(ctx context.Context, bookCopy *domain.BookCopy) (res *domain.BookCopy, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Find", begin, err)
+	}(time.Now())
+	return mw.Service.Find(ctx, bookCopy)
+}
+func (mw instrumentingMiddleware) Update(ctx context.Context, bookCopy *domain.BookCopy) (res *domain.BookCopy, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Update", begin, err)
+	}(time.Now())
+	return mw.Service.Update(ctx, bookCopy)
+}
+func (mw instrumentingMiddleware) Delete(ctx context.Context, bookCopy *domain.BookCopy) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Delete", begin, err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, bookCopy)
+}
@@ -0,0 +1,82 @@
+package book_copy
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw validationMiddleware) Create(ctx context.Context, bookCopy *domain.BookCopy) (err error) {
+	errs := validation.Errors{}
+	if bookCopy.BookID.IsZero() {
+		errs.Add("book_id", validation.CodeRequired, ErrBookIDIsRequired)
+	}
+	bookCopy.Barcode = strings.TrimSpace(bookCopy.Barcode)
+	if bookCopy.Barcode == "" {
+		errs.Add("barcode", validation.CodeRequired, ErrBarcodeIsRequired)
+	}
+	// a copy without condition is considered new
+	if bookCopy.Condition == "" {
+		bookCopy.Condition = domain.BookCopyConditionNew
+	}
+	if !bookCopy.Condition.IsValid() {
+		errs.Add("condition", validation.CodeInvalid, ErrConditionIsInvalid)
+	}
+	if bookCopy.AcquiredAt.After(time.Now()) {
+		errs.Add("acquired_at", validation.CodeOutOfRange, ErrAcquiredAtIsInvalid)
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, bookCopy)
+}
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.BookCopy, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "book_id", "condition", "barcode"); err != nil {
+		return nil, nil, err
+	}
+	if _, err := list.FilterUUID(q, "book_id"); err != nil {
+		return nil, nil, err
+	}
+	if condition := domain.BookCopyCondition(q.Filter("condition")); condition != "" && !condition.IsValid() {
+		return nil, nil, ErrConditionIsInvalid
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw validationMiddleware) Find(ctx context.Context, bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	return mw.Service.Find(ctx, bookCopy)
+}
+
+func (mw validationMiddleware) Update(ctx context.Context, bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	bookCopy.Barcode = strings.TrimSpace(bookCopy.Barcode)
+	errs := validation.Errors{}
+	if bookCopy.Condition != "" && !bookCopy.Condition.IsValid() {
+		errs.Add("condition", validation.CodeInvalid, ErrConditionIsInvalid)
+	}
+	if bookCopy.AcquiredAt.After(time.Now()) {
+		errs.Add("acquired_at", validation.CodeOutOfRange, ErrAcquiredAtIsInvalid)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return mw.Service.Update(ctx, bookCopy)
+}
+func (mw validationMiddleware) Delete(ctx context.Context, bookCopy *domain.BookCopy) error {
+	return mw.Service.Delete(ctx, bookCopy)
+}
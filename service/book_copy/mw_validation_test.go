@@ -0,0 +1,173 @@
+package book_copy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_Create(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CreateFunc: func(_ context.Context, p *domain.BookCopy) error {
+			return nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.BookCopy
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid bookCopy",
+			args: args{&domain.BookCopy{
+				BookID:        domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				Barcode:       "8934974123456",
+				Condition:     domain.BookCopyConditionGood,
+				ShelfLocation: "A-12",
+			}},
+		},
+		{
+			name: "valid bookCopy by missing condition",
+			args: args{&domain.BookCopy{
+				BookID:  domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				Barcode: "8934974123456",
+			}},
+		},
+		{
+			name: "invalid bookCopy by missing bookID",
+			args: args{&domain.BookCopy{
+				Barcode: "8934974123456",
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid bookCopy by blank barcode",
+			args: args{&domain.BookCopy{
+				BookID:  domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				Barcode: "   ",
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid bookCopy by unknown condition",
+			args: args{&domain.BookCopy{
+				BookID:    domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				Barcode:   "8934974123456",
+				Condition: "excellent",
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid bookCopy by acquired in the future",
+			args: args{&domain.BookCopy{
+				BookID:     domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				Barcode:    "8934974123456",
+				AcquiredAt: time.Now().Add(24 * time.Hour),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			err := mw.Create(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Create() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Create() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validationMiddleware_Update(t *testing.T) {
+	serviceMock := &ServiceMock{
+		UpdateFunc: func(_ context.Context, p *domain.BookCopy) (*domain.BookCopy, error) {
+			return p, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.BookCopy
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid bookCopy",
+			args: args{&domain.BookCopy{
+				Condition: domain.BookCopyConditionDamaged,
+			}},
+		},
+		{
+			name: "invalid bookCopy by unknown condition",
+			args: args{&domain.BookCopy{
+				Condition: "excellent",
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			_, err := mw.Update(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Update() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Update() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Update() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
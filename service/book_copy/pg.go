@@ -0,0 +1,197 @@
+package book_copy
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// pgService implmenter for BookCopy serivce in postgres
+type pgService struct {
+	db *gorm.DB
+}
+
+// NewPGService create new PGService
+func NewPGService(db *gorm.DB) Service {
+	return &pgService{
+		db: db,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// existBarcode check barcode is used by another book copy than exceptID,
+// deleted copies keep their barcodes
+func (s *pgService) existBarcode(barcode string, exceptID domain.UUID) (bool, error) {
+	var count int
+	q := s.db.Unscoped().Model(&domain.BookCopy{}).Where("barcode = ?", barcode)
+	if !exceptID.IsZero() {
+		q = q.Where("id <> ?", exceptID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// isExistBarcodeError check err is raised by unique constraint of barcode,
+// a concurrent copy of the same barcode may be stored after existBarcode
+func isExistBarcodeError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "book_copies_barcode_key"
+}
+
+// Create implement Create for BookCopy service
+func (s *pgService) Create(ctx context.Context, p *domain.BookCopy) error {
+	s = s.withContext(ctx)
+	// Check id of book exist in table books
+	var checkErr = s.db.Where("id = ?", p.BookID).Find(&domain.Book{}).Error
+	if checkErr != nil {
+		if checkErr == gorm.ErrRecordNotFound {
+			return ErrBookIDNotExist
+		}
+		return checkErr
+	}
+	exist, err := s.existBarcode(p.Barcode, domain.UUID{})
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ErrExistBarcode
+	}
+	if p.AcquiredAt.IsZero() {
+		p.AcquiredAt = time.Now()
+	}
+	if err := s.db.Create(p).Error; err != nil {
+		if isExistBarcodeError(err) {
+			return ErrExistBarcode
+		}
+		return err
+	}
+	return nil
+}
+
+// Update implement Update for BookCopy service
+func (s *pgService) Update(ctx context.Context, p *domain.BookCopy) (*domain.BookCopy, error) {
+	s = s.withContext(ctx)
+	old := domain.BookCopy{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Find(&old).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !p.BookID.IsZero() && p.BookID != old.BookID {
+		var checkErr = s.db.Where("id = ?", p.BookID).Find(&domain.Book{}).Error
+		if checkErr != nil {
+			if checkErr == gorm.ErrRecordNotFound {
+				return nil, ErrBookIDNotExist
+			}
+			return nil, checkErr
+		}
+		old.BookID = p.BookID
+	}
+	if p.Barcode != "" && p.Barcode != old.Barcode {
+		exist, err := s.existBarcode(p.Barcode, old.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, ErrExistBarcode
+		}
+		old.Barcode = p.Barcode
+	}
+	if p.Condition != "" {
+		old.Condition = p.Condition
+	}
+	if p.ShelfLocation != "" {
+		old.ShelfLocation = p.ShelfLocation
+	}
+	if !p.AcquiredAt.IsZero() {
+		old.AcquiredAt = p.AcquiredAt
+	}
+
+	if err := s.db.Save(&old).Error; err != nil {
+		if isExistBarcodeError(err) {
+			return nil, ErrExistBarcode
+		}
+		return nil, err
+	}
+	return &old, nil
+}
+
+// Find implement Find for BookCopy service
+func (s *pgService) Find(ctx context.Context, p *domain.BookCopy) (*domain.BookCopy, error) {
+	s = s.withContext(ctx)
+	res := p
+	if err := s.db.Find(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// FindAll implement FindAll for BookCopy service
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.BookCopy, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	bookID, err := list.FilterUUID(q, "book_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bookID.IsZero() {
+		db = db.Where("book_id = ?", bookID)
+	}
+	if condition := q.Filter("condition"); condition != "" {
+		db = db.Where("condition = ?", condition)
+	}
+	if barcode := q.Filter("barcode"); barcode != "" {
+		db = db.Where("barcode = ?", barcode)
+	}
+
+	res := []domain.BookCopy{}
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, meta, nil
+}
+
+// Delete implement Delete for BookCopy service
+func (s *pgService) Delete(ctx context.Context, p *domain.BookCopy) error {
+	s = s.withContext(ctx)
+	old := domain.BookCopy{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Find(&old).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrNotFound
+		}
+		return err
+	}
+	// a copy which is lended can not be removed from inventory
+	var count int
+	err := s.db.Model(&domain.LendBook{}).
+		Where("book_copy_id = ? AND returned_at IS NULL", old.ID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrLendedBookCopy
+	}
+	return s.db.Delete(old).Error
+}
@@ -0,0 +1,181 @@
+package book_copy
+
+import (
+	"context"
+	"testing"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestPGService_Create(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	// barcode of a deleted copy is still taken by it
+	deleted := domain.BookCopy{BookID: book.ID, Barcode: "8934974000000"}
+	err = testDB.Create(&deleted).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+	err = testDB.Delete(&deleted).Error
+	if err != nil {
+		t.Fatalf("Failed to delete bookCopy by error %v", err)
+	}
+
+	fakeBookID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p *domain.BookCopy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success create",
+			args: args{
+				&domain.BookCopy{
+					BookID:    book.ID,
+					Barcode:   "8934974123456",
+					Condition: domain.BookCopyConditionNew,
+				},
+			},
+		},
+		{
+			name: "failed create by exist barcode",
+			args: args{
+				&domain.BookCopy{
+					BookID:    book.ID,
+					Barcode:   "8934974123456",
+					Condition: domain.BookCopyConditionNew,
+				},
+			},
+			wantErr: ErrExistBarcode,
+		},
+		{
+			name: "failed create by barcode of deleted copy",
+			args: args{
+				&domain.BookCopy{
+					BookID:    book.ID,
+					Barcode:   "8934974000000",
+					Condition: domain.BookCopyConditionNew,
+				},
+			},
+			wantErr: ErrExistBarcode,
+		},
+		{
+			name: "failed create by not exist book id",
+			args: args{
+				&domain.BookCopy{
+					BookID:    fakeBookID,
+					Barcode:   "8934974654321",
+					Condition: domain.BookCopyConditionNew,
+				},
+			},
+			wantErr: ErrBookIDNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			err := s.Create(context.Background(), tt.args.p)
+			if err != nil && err != tt.wantErr {
+				t.Errorf("pgService.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.wantErr != nil {
+				t.Errorf("pgService.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGService_Delete(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	freeCopy := domain.BookCopy{BookID: book.ID, Barcode: "free"}
+	err = testDB.Create(&freeCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	lendedCopy := domain.BookCopy{BookID: book.ID, Barcode: "lended"}
+	err = testDB.Create(&lendedCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	err = testDB.Create(&domain.LendBook{BookID: book.ID, BookCopyID: lendedCopy.ID}).Error
+	if err != nil {
+		t.Fatalf("Failed to create lendBook by error %v", err)
+	}
+
+	fakeBookCopyID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p *domain.BookCopy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "success delete",
+			args:    args{&domain.BookCopy{Model: domain.Model{ID: freeCopy.ID}}},
+			wantErr: nil,
+		},
+		{
+			name:    "failed delete by lended copy",
+			args:    args{&domain.BookCopy{Model: domain.Model{ID: lendedCopy.ID}}},
+			wantErr: ErrLendedBookCopy,
+		},
+		{
+			name:    "failed delete by not exist bookCopy id",
+			args:    args{&domain.BookCopy{Model: domain.Model{ID: fakeBookCopyID}}},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			err := s.Delete(context.Background(), tt.args.p)
+			if err != nil && err != tt.wantErr {
+				t.Errorf("pgService.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.wantErr != nil {
+				t.Errorf("pgService.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
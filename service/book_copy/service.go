@@ -0,0 +1,27 @@
+package book_copy
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// sorting is fields which list of book copies can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at":  "created_at",
+		"acquired_at": "acquired_at",
+		"barcode":     "barcode",
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
+// Service interface for project service
+type Service interface {
+	Create(ctx context.Context, p *domain.BookCopy) error
+	Update(ctx context.Context, p *domain.BookCopy) (*domain.BookCopy, error)
+	Find(ctx context.Context, p *domain.BookCopy) (*domain.BookCopy, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.BookCopy, *domain.ListMeta, error)
+	Delete(ctx context.Context, p *domain.BookCopy) error
+}
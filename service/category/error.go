@@ -2,69 +2,16 @@ package category
 
 import (
 	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
 )
 
 // Error Declaration
 var (
-	ErrNotFound       = errNotFound{}
-	ErrUnknown        = errUnknown{}
-	ErrRecordNotFound = errRecordNotFound{}
-	ErrNameIsRequired = errNameIsRequired{}
-	ErrminimumLength  = errMinimumLength{}
-	ErrExistName      = errExistName{}
+	ErrNotFound       = apperror.New("category.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown        = apperror.New("category.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound = apperror.New("category.record_not_found", http.StatusNotFound, "client record not found")
+	ErrNameIsRequired = apperror.New("category.name_is_required", http.StatusBadRequest, "Category name is required")
+	ErrminimumLength  = apperror.New("category.minimum_length", http.StatusBadRequest, "Name of category is length > 5 characters")
+	ErrExistName      = apperror.New("category.exist_name", http.StatusBadRequest, "Name is exist in database")
 )
-
-type errNotFound struct{}
-
-func (errNotFound) Error() string {
-	return "record not found"
-}
-func (errNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errUnknown struct{}
-
-func (errUnknown) Error() string {
-	return "unknown error"
-}
-func (errUnknown) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errRecordNotFound struct{}
-
-func (errRecordNotFound) Error() string {
-	return "client record not found"
-}
-func (errRecordNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errNameIsRequired struct{}
-
-func (errNameIsRequired) Error() string {
-	return "Category name is required"
-}
-
-func (errNameIsRequired) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errMinimumLength struct{}
-
-func (errMinimumLength) Error() string {
-	return "Name of category is length > 5 characters"
-}
-func (errMinimumLength) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errExistName struct{}
-
-func (errExistName) Error() string {
-	return "Name is exist in database"
-}
-func (errExistName) StatusCode() int {
-	return http.StatusBadRequest
-}
@@ -203,6 +203,7 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 	}
 	type args struct {
 		ctx context.Context
+		q   *domain.ListQuery
 	}
 	tests := []struct {
 		name       string
@@ -218,7 +219,7 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 			mw := validationMiddleware{
 				Service: tt.fields.Service,
 			}
-			gotOutput, err := mw.FindAll(tt.args.ctx)
+			gotOutput, _, err := mw.FindAll(tt.args.ctx, tt.args.q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validationMiddleware.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,56 @@
+package category
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type loggingMiddleware struct {
+	Service
+	logger log.Logger
+}
+
+// LoggingMiddleware log every call of service with its duration and error
+func LoggingMiddleware(logger log.Logger) func(Service) Service {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			Service: next,
+			logger:  log.With(logger, "service", "category"),
+		}
+	}
+}
+
+func (mw loggingMiddleware) Create(ctx context.Context, category *domain.Category) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "category_id", category.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Create(ctx, category)
+}
+func (mw loggingMiddleware) Update(ctx context.Context, category *domain.Category) (res *domain.Category, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Update", "category_id", category.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Update(ctx, category)
+}
+func (mw loggingMiddleware) Find(ctx context.Context, category *domain.Category) (res *domain.Category, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "category_id", category.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Find(ctx, category)
+}
+func (mw loggingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.Category, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "FindAll", "count", len(res), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw loggingMiddleware) Delete(ctx context.Context, category *domain.Category) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "category_id", category.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, category)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
 )
 
 // Declare Regex
@@ -21,30 +23,36 @@ func ValidationMiddleware() func(Service) Service {
 	}
 }
 
-func (mw validationMiddleware) Create(ctx context.Context, category *domain.Category) (err error) {
-	//Check length and empty of name
+// validate check length and empty of name
+func validate(category *domain.Category) error {
+	errs := validation.Errors{}
 	if category.Name == "" {
-		return ErrNameIsRequired
+		errs.Add("name", validation.CodeRequired, ErrNameIsRequired)
+	} else if len(category.Name) <= 5 {
+		errs.Add("name", validation.CodeTooShort, ErrminimumLength)
 	}
-	if len(category.Name) <= 5 {
-		return ErrminimumLength
+	return errs.Err()
+}
+
+func (mw validationMiddleware) Create(ctx context.Context, category *domain.Category) (err error) {
+	if err := validate(category); err != nil {
+		return err
 	}
 	return mw.Service.Create(ctx, category)
 }
-func (mw validationMiddleware) FindAll(ctx context.Context) ([]domain.Category, error) {
-	return mw.Service.FindAll(ctx)
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Category, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "name"); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
 }
 func (mw validationMiddleware) Find(ctx context.Context, category *domain.Category) (*domain.Category, error) {
 	return mw.Service.Find(ctx, category)
 }
 
 func (mw validationMiddleware) Update(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	//Check length and empty of name
-	if category.Name == "" {
-		return nil, ErrNameIsRequired
-	}
-	if len(category.Name) <= 5 {
-		return nil, ErrminimumLength
+	if err := validate(category); err != nil {
+		return nil, err
 	}
 	return mw.Service.Update(ctx, category)
 }
@@ -5,7 +5,10 @@ import (
 
 	"github.com/jinzhu/gorm"
 
+	"github.com/phungvandat/example-go/config/database/pg"
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
 )
 
 // pgService implmenter for Category serivce in postgres
@@ -20,8 +23,16 @@ func NewPGService(db *gorm.DB) Service {
 	}
 }
 
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
 // Create implement Create for Category service
-func (s *pgService) Create(_ context.Context, p *domain.Category) error {
+func (s *pgService) Create(ctx context.Context, p *domain.Category) error {
+	s = s.withContext(ctx)
 	var err = s.db.Create(p).Error
 	//Check name category is exist in table categories
 	if err != nil {
@@ -31,7 +42,8 @@ func (s *pgService) Create(_ context.Context, p *domain.Category) error {
 }
 
 // Update implement Update for Category service
-func (s *pgService) Update(_ context.Context, p *domain.Category) (*domain.Category, error) {
+func (s *pgService) Update(ctx context.Context, p *domain.Category) (*domain.Category, error) {
+	s = s.withContext(ctx)
 	old := domain.Category{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -51,7 +63,8 @@ func (s *pgService) Update(_ context.Context, p *domain.Category) (*domain.Categ
 }
 
 // Find implement Find for Category service
-func (s *pgService) Find(_ context.Context, p *domain.Category) (*domain.Category, error) {
+func (s *pgService) Find(ctx context.Context, p *domain.Category) (*domain.Category, error) {
+	s = s.withContext(ctx)
 	res := p
 	if err := s.db.Find(&res).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -64,13 +77,24 @@ func (s *pgService) Find(_ context.Context, p *domain.Category) (*domain.Categor
 }
 
 // FindAll implement FindAll for Category service
-func (s *pgService) FindAll(_ context.Context) ([]domain.Category, error) {
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Category, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	if name := q.Filter("name"); name != "" {
+		db = db.Where("name ILIKE ?", list.Contains(name))
+	}
+
 	res := []domain.Category{}
-	return res, s.db.Find(&res).Error
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, meta, nil
 }
 
 // Delete implement Delete for Category service
-func (s *pgService) Delete(_ context.Context, p *domain.Category) error {
+func (s *pgService) Delete(ctx context.Context, p *domain.Category) error {
+	s = s.withContext(ctx)
 	old := domain.Category{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
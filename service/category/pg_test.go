@@ -187,6 +187,7 @@ func TestPGService_FindAll(t *testing.T) {
 	}
 	type args struct {
 		in0 context.Context
+		q   *domain.ListQuery
 	}
 	tests := []struct {
 		name    string
@@ -202,7 +203,7 @@ func TestPGService_FindAll(t *testing.T) {
 			s := &pgService{
 				db: tt.fields.db,
 			}
-			got, err := s.FindAll(tt.args.in0)
+			got, _, err := s.FindAll(tt.args.in0, tt.args.q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("pgService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
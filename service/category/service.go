@@ -4,13 +4,23 @@ import (
 	"context"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
 )
 
+// sorting is fields which list of categories can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
 // Service interface for project service
 type Service interface {
 	Create(ctx context.Context, p *domain.Category) error
 	Update(ctx context.Context, p *domain.Category) (*domain.Category, error)
 	Find(ctx context.Context, p *domain.Category) (*domain.Category, error)
-	FindAll(ctx context.Context) ([]domain.Category, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Category, *domain.ListMeta, error)
 	Delete(ctx context.Context, p *domain.Category) error
 }
@@ -0,0 +1,20 @@
+package fine
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrNotFound             = apperror.New("fine.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown              = apperror.New("fine.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound       = apperror.New("fine.record_not_found", http.StatusNotFound, "client record not found")
+	ErrUserIDIsRequired     = apperror.New("fine.user_id_is_required", http.StatusBadRequest, "ID of user is required")
+	ErrUserIDNotExist       = apperror.New("fine.user_id_not_exist", http.StatusNotFound, "ID of user not exist in table users")
+	ErrLendBookIDIsRequired = apperror.New("fine.lend_book_id_is_required", http.StatusBadRequest, "ID of lend book is required")
+	ErrLendBookIDNotExist   = apperror.New("fine.lend_book_id_not_exist", http.StatusNotFound, "ID of lend book not exist in table lend_books")
+	ErrAmountIsInvalid      = apperror.New("fine.amount_is_invalid", http.StatusBadRequest, "Amount must be greater than 0")
+	ErrPaymentExceedBalance = apperror.New("fine.payment_exceed_balance", http.StatusBadRequest, "Payment is greater than balance of user")
+)
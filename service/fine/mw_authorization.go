@@ -0,0 +1,59 @@
+package fine
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits members to read and pay their own fines,
+// staff to act on fines of anyone
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Accrue(ctx context.Context, user *domain.User) error {
+	// a member may charge own fines, while charging fines of all users is
+	// left to staff
+	if user == nil {
+		if _, err := auth.RequireStaff(ctx); err != nil {
+			return err
+		}
+	} else if _, err := auth.RequireSelfOrStaff(ctx, user.ID); err != nil {
+		return err
+	}
+	return mw.Service.Accrue(ctx, user)
+}
+func (mw authorizationMiddleware) AccrueLendBook(ctx context.Context, lendBook *domain.LendBook) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.AccrueLendBook(ctx, lendBook)
+}
+func (mw authorizationMiddleware) Balance(ctx context.Context, user *domain.User) (*domain.Balance, error) {
+	if _, err := auth.RequireSelfOrStaff(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return mw.Service.Balance(ctx, user)
+}
+func (mw authorizationMiddleware) FindAllByUser(ctx context.Context, user *domain.User) ([]domain.LedgerEntry, error) {
+	if _, err := auth.RequireSelfOrStaff(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return mw.Service.FindAllByUser(ctx, user)
+}
+func (mw authorizationMiddleware) Pay(ctx context.Context, entry *domain.LedgerEntry) error {
+	if _, err := auth.RequireSelfOrStaff(ctx, entry.UserID); err != nil {
+		return err
+	}
+	return mw.Service.Pay(ctx, entry)
+}
@@ -0,0 +1,69 @@
+package fine
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "fine"),
+			errors:   errors.With("service", "fine"),
+			latency:  latency.With("service", "fine"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Accrue(ctx context.Context, user *domain.User) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Accrue", begin, err)
+	}(time.Now())
+	return mw.Service.Accrue(ctx, user)
+}
+func (mw instrumentingMiddleware) AccrueLendBook(ctx context.Context, lendBook *domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("AccrueLendBook", begin, err)
+	}(time.Now())
+	return mw.Service.AccrueLendBook(ctx, lendBook)
+}
+func (mw instrumentingMiddleware) Balance(ctx context.Context, user *domain.User) (res *domain.Balance, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Balance", begin, err)
+	}(time.Now())
+	return mw.Service.Balance(ctx, user)
+}
+func (mw instrumentingMiddleware) FindAllByUser(ctx context.Context, user *domain.User) (res []domain.LedgerEntry, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAllByUser", begin, err)
+	}(time.Now())
+	return mw.Service.FindAllByUser(ctx, user)
+}
+func (mw instrumentingMiddleware) Pay(ctx context.Context, entry *domain.LedgerEntry) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Pay", begin, err)
+	}(time.Now())
+	return mw.Service.Pay(ctx, entry)
+}
@@ -0,0 +1,57 @@
+package fine
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw validationMiddleware) Accrue(ctx context.Context, user *domain.User) error {
+	return mw.Service.Accrue(ctx, user)
+}
+func (mw validationMiddleware) AccrueLendBook(ctx context.Context, lendBook *domain.LendBook) error {
+	if lendBook.ID.IsZero() {
+		return ErrLendBookIDIsRequired
+	}
+	return mw.Service.AccrueLendBook(ctx, lendBook)
+}
+func (mw validationMiddleware) Balance(ctx context.Context, user *domain.User) (*domain.Balance, error) {
+	if user.ID.IsZero() {
+		return nil, ErrUserIDIsRequired
+	}
+	return mw.Service.Balance(ctx, user)
+}
+func (mw validationMiddleware) FindAllByUser(ctx context.Context, user *domain.User) ([]domain.LedgerEntry, error) {
+	if user.ID.IsZero() {
+		return nil, ErrUserIDIsRequired
+	}
+	return mw.Service.FindAllByUser(ctx, user)
+}
+func (mw validationMiddleware) Pay(ctx context.Context, entry *domain.LedgerEntry) error {
+	errs := validation.Errors{}
+	if entry.UserID.IsZero() {
+		errs.Add("user_id", validation.CodeRequired, ErrUserIDIsRequired)
+	}
+	// payment is given as positive amount, it is stored as negative one
+	if entry.Amount <= 0 {
+		errs.Add("amount", validation.CodeOutOfRange, ErrAmountIsInvalid)
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+	return mw.Service.Pay(ctx, entry)
+}
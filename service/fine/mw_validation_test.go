@@ -0,0 +1,89 @@
+package fine
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_Pay(t *testing.T) {
+	serviceMock := &ServiceMock{
+		PayFunc: func(_ context.Context, p *domain.LedgerEntry) error {
+			return nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.LedgerEntry
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid payment",
+			args: args{&domain.LedgerEntry{
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+				Amount: 500,
+			}},
+		},
+		{
+			name: "invalid payment by missing userID",
+			args: args{&domain.LedgerEntry{
+				Amount: 500,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payment by zero amount",
+			args: args{&domain.LedgerEntry{
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payment by negative amount",
+			args: args{&domain.LedgerEntry{
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+				Amount: -500,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			err := mw.Pay(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Pay() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Pay() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Pay() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Pay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
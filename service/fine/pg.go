@@ -0,0 +1,206 @@
+package fine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// pgService implmenter for Fine serivce in postgres
+type pgService struct {
+	db *gorm.DB
+}
+
+// NewPGService create new PGService
+func NewPGService(db *gorm.DB) Service {
+	return &pgService{
+		db: db,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// fineOf compute total fine of lend book at time now, finePerDay
+// is charged for each started day after To
+func fineOf(lendBook *domain.LendBook, finePerDay int64, now time.Time) (days int64, amount int64) {
+	end := now
+	if lendBook.ReturnedAt != nil {
+		end = *lendBook.ReturnedAt
+	}
+	if !end.After(lendBook.To) {
+		return 0, 0
+	}
+	days = int64(math.Ceil(end.Sub(lendBook.To).Hours() / 24))
+	return days, days * finePerDay
+}
+
+// unsettledLendBookCond is condition of lend books which are past To and
+// whose fine may still grow: not returned, or returned late and not charged
+// up to ReturnedAt yet
+const unsettledLendBookCond = `"to" < ? AND (returned_at IS NULL
+	OR (returned_at > "to" AND (fined_through IS NULL OR fined_through < returned_at)))`
+
+// accrueLendBook charge fine of lend book which is not charged yet, tx must
+// be a transaction as lend book is locked until it ends
+func accrueLendBook(tx *gorm.DB, lendBookID domain.UUID, now time.Time) error {
+	// lock lend book so a fine is charged once
+	lendBook := domain.LendBook{}
+	err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Where("id = ?", lendBookID).
+		First(&lendBook).Error
+	if err != nil {
+		return err
+	}
+
+	var charged struct{ Amount int64 }
+	err = tx.Raw(`SELECT COALESCE(SUM(amount), 0) AS amount FROM ledger_entries
+		WHERE lend_book_id = ? AND kind = ? AND deleted_at IS NULL`,
+		lendBook.ID, domain.LedgerEntryKindFine).Scan(&charged).Error
+	if err != nil {
+		return err
+	}
+
+	policy := domain.DefaultLendingPolicy()
+	if !lendBook.LendingPolicyID.IsZero() {
+		// policy which was resolved for lend book is used even though it is deleted
+		policy = &domain.LendingPolicy{}
+		err := tx.Unscoped().Where("id = ?", lendBook.LendingPolicyID).First(policy).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	days, amount := fineOf(&lendBook, policy.FinePerDay, now)
+	if amount > charged.Amount {
+		err := tx.Create(&domain.LedgerEntry{
+			UserID:     lendBook.UserID,
+			LendBookID: lendBook.ID,
+			Kind:       domain.LedgerEntryKindFine,
+			Amount:     amount - charged.Amount,
+			Note:       fmt.Sprintf("Overdue fine for %d days", days),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	finedThrough := now
+	if lendBook.ReturnedAt != nil {
+		finedThrough = *lendBook.ReturnedAt
+	}
+	return tx.Model(&lendBook).UpdateColumn("fined_through", finedThrough).Error
+}
+
+// accrue charge fines of lend books of user which are not settled, fines of
+// all users are accrued when user is nil. Each lend book is charged in its
+// own transaction unless db is a transaction already
+func (s *pgService) accrue(user *domain.User, now time.Time) error {
+	q := s.db.Model(&domain.LendBook{}).Where(unsettledLendBookCond, now)
+	if user != nil && !user.ID.IsZero() {
+		q = q.Where("user_id = ?", user.ID)
+	}
+
+	lendBookIDs := []domain.UUID{}
+	if err := q.Pluck("id", &lendBookIDs).Error; err != nil {
+		return err
+	}
+	for _, id := range lendBookIDs {
+		err := pg.Transaction(s.db, func(tx *gorm.DB) error {
+			return accrueLendBook(tx, id, now)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// balance sum ledger entries of user
+func (s *pgService) balance(userID domain.UUID) (int64, error) {
+	var sum struct{ Amount int64 }
+	err := s.db.Raw(`SELECT COALESCE(SUM(amount), 0) AS amount FROM ledger_entries
+		WHERE user_id = ? AND deleted_at IS NULL`, userID).Scan(&sum).Error
+	return sum.Amount, err
+}
+
+// Accrue implement Accrue for Fine service, fines of all users
+// are accrued when ID of user is not given
+func (s *pgService) Accrue(ctx context.Context, p *domain.User) error {
+	s = s.withContext(ctx)
+	return s.accrue(p, time.Now())
+}
+
+// AccrueLendBook implement AccrueLendBook for Fine service, it charges fine
+// of one lend book, which is done when the book is returned
+func (s *pgService) AccrueLendBook(ctx context.Context, p *domain.LendBook) error {
+	s = s.withContext(ctx)
+	err := pg.Transaction(s.db, func(tx *gorm.DB) error {
+		return accrueLendBook(tx, p.ID, time.Now())
+	})
+	if err == gorm.ErrRecordNotFound {
+		return ErrLendBookIDNotExist
+	}
+	return err
+}
+
+// Balance implement Balance for Fine service, it reads fines which are
+// accrued already, they are accrued by job of server and on lend and pay
+func (s *pgService) Balance(ctx context.Context, p *domain.User) (*domain.Balance, error) {
+	s = s.withContext(ctx)
+	amount, err := s.balance(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Balance{UserID: p.ID, Amount: amount}, nil
+}
+
+// FindAllByUser implement FindAllByUser for Fine service
+func (s *pgService) FindAllByUser(ctx context.Context, p *domain.User) ([]domain.LedgerEntry, error) {
+	s = s.withContext(ctx)
+	res := []domain.LedgerEntry{}
+	return res, s.db.Where("user_id = ?", p.ID).Order("created_at").Find(&res).Error
+}
+
+// Pay implement Pay for Fine service, user is locked while balance is
+// checked so concurrent payments can not exceed it together
+func (s *pgService) Pay(ctx context.Context, p *domain.LedgerEntry) error {
+	s = s.withContext(ctx)
+	return pg.Transaction(s.db, func(tx *gorm.DB) error {
+		txService := &pgService{db: tx}
+		user := &domain.User{Model: domain.Model{ID: p.UserID}}
+		if err := tx.Set("gorm:query_option", "FOR UPDATE").Find(user).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrUserIDNotExist
+			}
+			return err
+		}
+
+		if err := txService.accrue(user, time.Now()); err != nil {
+			return err
+		}
+		balance, err := txService.balance(user.ID)
+		if err != nil {
+			return err
+		}
+		if p.Amount > balance {
+			return ErrPaymentExceedBalance
+		}
+
+		p.Kind = domain.LedgerEntryKindPayment
+		p.Amount = -p.Amount
+		p.LendBookID = domain.UUID{}
+		return tx.Create(p).Error
+	})
+}
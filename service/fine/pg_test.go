@@ -0,0 +1,196 @@
+package fine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_fineOf(t *testing.T) {
+	const finePerDay = 100
+	now := time.Now()
+	returnedAt := now.AddDate(0, 0, -1)
+	tests := []struct {
+		name       string
+		lendBook   *domain.LendBook
+		wantDays   int64
+		wantAmount int64
+	}{
+		{
+			name:     "not due yet",
+			lendBook: &domain.LendBook{To: now.Add(time.Hour)},
+		},
+		{
+			name:       "out past To",
+			lendBook:   &domain.LendBook{To: now.Add(-49 * time.Hour)},
+			wantDays:   3,
+			wantAmount: 3 * finePerDay,
+		},
+		{
+			name:       "returned late",
+			lendBook:   &domain.LendBook{To: now.AddDate(0, 0, -3), ReturnedAt: &returnedAt},
+			wantDays:   2,
+			wantAmount: 2 * finePerDay,
+		},
+		{
+			name:     "returned in time",
+			lendBook: &domain.LendBook{To: now, ReturnedAt: &returnedAt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, amount := fineOf(tt.lendBook, finePerDay, now)
+			if days != tt.wantDays || amount != tt.wantAmount {
+				t.Errorf("fineOf() = %v, %v, want %v, %v", days, amount, tt.wantDays, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestPGService_Accrue(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+	err = testDB.Create(&domain.LendBook{
+		UserID: user.ID,
+		From:   time.Now().AddDate(0, 0, -20),
+		To:     time.Now().Add(-49 * time.Hour),
+	}).Error
+	if err != nil {
+		t.Fatalf("Failed to create lendBook by error %v", err)
+	}
+	returnedAt := time.Now().AddDate(0, 0, -10)
+	returned := domain.LendBook{
+		UserID:     user.ID,
+		From:       time.Now().AddDate(0, 0, -20),
+		To:         returnedAt.Add(-25 * time.Hour),
+		ReturnedAt: &returnedAt,
+	}
+	err = testDB.Create(&returned).Error
+	if err != nil {
+		t.Fatalf("Failed to create lendBook by error %v", err)
+	}
+
+	finePerDay := domain.DefaultLendingPolicy().FinePerDay
+	s := &pgService{
+		db: testDB,
+	}
+	// balance is a read, so it charges no fine
+	balance, err := s.Balance(context.Background(), &user)
+	if err != nil {
+		t.Fatalf("pgService.Balance() error = %v", err)
+	}
+	if balance.Amount != 0 {
+		t.Errorf("pgService.Balance() amount before Accrue() = %v, want 0", balance.Amount)
+	}
+
+	// fines are accrued twice, each one must be charged once
+	for i := 0; i < 2; i++ {
+		if err := s.Accrue(context.Background(), &user); err != nil {
+			t.Fatalf("pgService.Accrue() error = %v", err)
+		}
+		balance, err := s.Balance(context.Background(), &user)
+		if err != nil {
+			t.Fatalf("pgService.Balance() error = %v", err)
+		}
+		if balance.Amount != 5*finePerDay {
+			t.Errorf("pgService.Balance() amount = %v, want %v", balance.Amount, 5*finePerDay)
+		}
+	}
+
+	// returned lend book whose fine is charged is not accrued again
+	var unsettled int
+	err = testDB.Model(&domain.LendBook{}).Where(unsettledLendBookCond, time.Now()).Count(&unsettled).Error
+	if err != nil {
+		t.Fatalf("Failed to count lendBook by error %v", err)
+	}
+	if unsettled != 1 {
+		t.Errorf("pgService.Accrue() left %v unsettled lendBooks, want 1", unsettled)
+	}
+
+	entries, err := s.FindAllByUser(context.Background(), &user)
+	if err != nil {
+		t.Fatalf("pgService.FindAllByUser() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("pgService.FindAllByUser() len = %v, want %v", len(entries), 2)
+	}
+}
+
+func TestPGService_Pay(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+	err = testDB.Create(&domain.LedgerEntry{
+		UserID: user.ID,
+		Kind:   domain.LedgerEntryKindFine,
+		Amount: 500,
+	}).Error
+	if err != nil {
+		t.Fatalf("Failed to create ledgerEntry by error %v", err)
+	}
+
+	fakeUserID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p *domain.LedgerEntry
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "failed pay by not exist user",
+			args:    args{&domain.LedgerEntry{UserID: fakeUserID, Amount: 100}},
+			wantErr: ErrUserIDNotExist,
+		},
+		{
+			name:    "failed pay by exceed balance",
+			args:    args{&domain.LedgerEntry{UserID: user.ID, Amount: 600}},
+			wantErr: ErrPaymentExceedBalance,
+		},
+		{
+			name: "success pay",
+			args: args{&domain.LedgerEntry{UserID: user.ID, Amount: 500}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			err := s.Pay(context.Background(), tt.args.p)
+			if err != tt.wantErr {
+				t.Errorf("pgService.Pay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.args.p.Amount != -500 {
+				t.Errorf("pgService.Pay() amount = %v, want %v", tt.args.p.Amount, -500)
+			}
+		})
+	}
+}
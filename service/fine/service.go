@@ -0,0 +1,16 @@
+package fine
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Service interface for project service
+type Service interface {
+	Accrue(ctx context.Context, p *domain.User) error
+	AccrueLendBook(ctx context.Context, p *domain.LendBook) error
+	Balance(ctx context.Context, p *domain.User) (*domain.Balance, error)
+	FindAllByUser(ctx context.Context, p *domain.User) ([]domain.LedgerEntry, error)
+	Pay(ctx context.Context, p *domain.LedgerEntry) error
+}
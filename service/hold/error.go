@@ -0,0 +1,21 @@
+package hold
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrNotFound         = apperror.New("hold.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown          = apperror.New("hold.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound   = apperror.New("hold.record_not_found", http.StatusNotFound, "client record not found")
+	ErrIDIsRequired     = apperror.New("hold.id_is_required", http.StatusBadRequest, "ID of hold is required")
+	ErrBookIDIsRequired = apperror.New("hold.book_id_is_required", http.StatusBadRequest, "ID of book is required")
+	ErrUserIDIsRequired = apperror.New("hold.user_id_is_required", http.StatusBadRequest, "ID of user is required")
+	ErrBookIDNotExist   = apperror.New("hold.book_id_not_exist", http.StatusNotFound, "ID of book not exist in table books")
+	ErrUserIDNotExist   = apperror.New("hold.user_id_not_exist", http.StatusNotFound, "ID of user not exist in table users")
+	ErrExistHold        = apperror.New("hold.exist_hold", http.StatusBadRequest, "The user already has a hold on the book")
+	ErrClosedHold       = apperror.New("hold.closed_hold", http.StatusBadRequest, "The hold is fulfilled, expired or cancelled")
+)
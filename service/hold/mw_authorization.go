@@ -0,0 +1,67 @@
+package hold
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits members to place, read and cancel their own
+// holds, staff to act on holds of anyone
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, hold *domain.Hold) error {
+	if _, err := auth.RequireSelfOrStaff(ctx, hold.UserID); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, hold)
+}
+func (mw authorizationMiddleware) Cancel(ctx context.Context, hold *domain.Hold) (*domain.Hold, error) {
+	if _, err := mw.Find(ctx, hold); err != nil {
+		return nil, err
+	}
+	return mw.Service.Cancel(ctx, hold)
+}
+func (mw authorizationMiddleware) Find(ctx context.Context, hold *domain.Hold) (*domain.Hold, error) {
+	p, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := mw.Service.Find(ctx, hold)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Role.IsStaff() && res.UserID != p.UserID {
+		return nil, auth.ErrForbidden
+	}
+	return res, nil
+}
+func (mw authorizationMiddleware) FindAllByUser(ctx context.Context, user *domain.User) ([]domain.Hold, error) {
+	if _, err := auth.RequireSelfOrStaff(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return mw.Service.FindAllByUser(ctx, user)
+}
+func (mw authorizationMiddleware) AssignBookCopy(ctx context.Context, bookCopy *domain.BookCopy) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.AssignBookCopy(ctx, bookCopy)
+}
+func (mw authorizationMiddleware) Expire(ctx context.Context) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.Expire(ctx)
+}
@@ -0,0 +1,75 @@
+package hold
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "hold"),
+			errors:   errors.With("service", "hold"),
+			latency:  latency.With("service", "hold"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Create(ctx context.Context, hold *domain.Hold) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Create", begin, err)
+	}(time.Now())
+	return mw.Service.Create(ctx, hold)
+}
+func (mw instrumentingMiddleware) Cancel(ctx context.Context, hold *domain.Hold) (res *domain.Hold, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Cancel", begin, err)
+	}(time.Now())
+	return mw.Service.Cancel(ctx, hold)
+}
+func (mw instrumentingMiddleware) Find(ctx context.Context, hold *domain.Hold) (res *domain.Hold, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Find", begin, err)
+	}(time.Now())
+	return mw.Service.Find(ctx, hold)
+}
+func (mw instrumentingMiddleware) FindAllByUser(ctx context.Context, user *domain.User) (res []domain.Hold, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAllByUser", begin, err)
+	}(time.Now())
+	return mw.Service.FindAllByUser(ctx, user)
+}
+func (mw instrumentingMiddleware) AssignBookCopy(ctx context.Context, bookCopy *domain.BookCopy) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("AssignBookCopy", begin, err)
+	}(time.Now())
+	return mw.Service.AssignBookCopy(ctx, bookCopy)
+}
+func (mw instrumentingMiddleware) Expire(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Expire", begin, err)
+	}(time.Now())
+	return mw.Service.Expire(ctx)
+}
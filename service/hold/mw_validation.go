@@ -0,0 +1,62 @@
+package hold
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw validationMiddleware) Create(ctx context.Context, hold *domain.Hold) (err error) {
+	errs := validation.Errors{}
+	if hold.BookID.IsZero() {
+		errs.Add("book_id", validation.CodeRequired, ErrBookIDIsRequired)
+	}
+	if hold.UserID.IsZero() {
+		errs.Add("user_id", validation.CodeRequired, ErrUserIDIsRequired)
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, hold)
+}
+func (mw validationMiddleware) Cancel(ctx context.Context, hold *domain.Hold) (*domain.Hold, error) {
+	if hold.ID.IsZero() {
+		return nil, ErrIDIsRequired
+	}
+	return mw.Service.Cancel(ctx, hold)
+}
+func (mw validationMiddleware) Find(ctx context.Context, hold *domain.Hold) (*domain.Hold, error) {
+	if hold.ID.IsZero() {
+		return nil, ErrIDIsRequired
+	}
+	return mw.Service.Find(ctx, hold)
+}
+func (mw validationMiddleware) FindAllByUser(ctx context.Context, user *domain.User) ([]domain.Hold, error) {
+	if user.ID.IsZero() {
+		return nil, ErrUserIDIsRequired
+	}
+	return mw.Service.FindAllByUser(ctx, user)
+}
+func (mw validationMiddleware) AssignBookCopy(ctx context.Context, bookCopy *domain.BookCopy) error {
+	if bookCopy.BookID.IsZero() {
+		return ErrBookIDIsRequired
+	}
+	return mw.Service.AssignBookCopy(ctx, bookCopy)
+}
+func (mw validationMiddleware) Expire(ctx context.Context) error {
+	return mw.Service.Expire(ctx)
+}
@@ -0,0 +1,140 @@
+package hold
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_Create(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CreateFunc: func(_ context.Context, p *domain.Hold) error {
+			return nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.Hold
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid hold",
+			args: args{&domain.Hold{
+				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+			}},
+		},
+		{
+			name: "invalid hold by missing bookID",
+			args: args{&domain.Hold{
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid hold by missing userID",
+			args: args{&domain.Hold{
+				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			err := mw.Create(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Create() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Create() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validationMiddleware_Cancel(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CancelFunc: func(_ context.Context, p *domain.Hold) (*domain.Hold, error) {
+			return p, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.Hold
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid hold",
+			args: args{&domain.Hold{
+				Model: domain.Model{ID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")},
+			}},
+		},
+		{
+			name:            "invalid hold by missing id",
+			args:            args{&domain.Hold{}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			_, err := mw.Cancel(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Cancel() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Cancel() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Cancel() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Cancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
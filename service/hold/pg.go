@@ -0,0 +1,262 @@
+package hold
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// pickupPeriod is how long a book copy is kept for a ready hold
+const pickupPeriod = 3 * 24 * time.Hour
+
+// freeBookCopyCond is condition of a book copy which can be kept for a hold
+// in range [from, to): it is not lended in the range, as overlapLendBookCond
+// of lend books, and not kept for a ready hold
+const freeBookCopyCond = `NOT EXISTS (
+	SELECT 1 FROM lend_books l
+	WHERE l.book_copy_id = book_copies.id AND l.returned_at IS NULL AND l.deleted_at IS NULL
+	AND tstzrange(l."from", GREATEST(l."to", now())) && tstzrange(?, ?)
+) AND NOT EXISTS (
+	SELECT 1 FROM holds h
+	WHERE h.book_copy_id = book_copies.id AND h.status = 'ready' AND h.deleted_at IS NULL
+)`
+
+// pgService implmenter for Hold serivce in postgres
+type pgService struct {
+	db *gorm.DB
+}
+
+// NewPGService create new PGService
+func NewPGService(db *gorm.DB) Service {
+	return &pgService{
+		db: db,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// isExistHoldError check err is raised by unique index of open holds, which
+// guarantees a user has one open hold per book
+func isExistHoldError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "holds_open_book_id_user_id_idx"
+}
+
+// assignFreeCopies give free copies of book to waiting holds in order of
+// creation, a copy is free when it is not booked before pickup deadline
+func (s *pgService) assignFreeCopies(bookID domain.UUID) error {
+	return pg.Transaction(s.db, func(tx *gorm.DB) error {
+		for {
+			now := time.Now()
+			deadline := now.Add(pickupPeriod)
+
+			bookCopy := domain.BookCopy{}
+			err := tx.Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").
+				Where("book_id = ?", bookID).
+				Where(freeBookCopyCond, now, deadline).
+				First(&bookCopy).Error
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			hold := domain.Hold{}
+			err = tx.Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").
+				Where("book_id = ? AND status = ?", bookID, domain.HoldStatusWaiting).
+				Order("created_at").
+				First(&hold).Error
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			hold.Status = domain.HoldStatusReady
+			hold.BookCopyID = bookCopy.ID
+			hold.ReadyAt = &now
+			hold.PickupDeadline = &deadline
+			if err := tx.Save(&hold).Error; err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// fillPosition compute position in queue of book for waiting hold
+func (s *pgService) fillPosition(hold *domain.Hold) error {
+	if hold.Status != domain.HoldStatusWaiting {
+		hold.Position = 0
+		return nil
+	}
+	return s.db.Model(&domain.Hold{}).
+		Where("book_id = ? AND status = ? AND created_at <= ?", hold.BookID, domain.HoldStatusWaiting, hold.CreatedAt).
+		Count(&hold.Position).Error
+}
+
+// Create implement Create for Hold service
+func (s *pgService) Create(ctx context.Context, p *domain.Hold) error {
+	s = s.withContext(ctx)
+	var errExistBoID = s.db.Where("id = ?", p.BookID).Find(&domain.Book{}).Error
+	if errExistBoID != nil {
+		if errExistBoID == gorm.ErrRecordNotFound {
+			return ErrBookIDNotExist
+		}
+		return errExistBoID
+	}
+	var errExistUsID = s.db.Where("id = ?", p.UserID).Find(&domain.User{}).Error
+	if errExistUsID != nil {
+		if errExistUsID == gorm.ErrRecordNotFound {
+			return ErrUserIDNotExist
+		}
+		return errExistUsID
+	}
+	if err := s.Expire(ctx); err != nil {
+		return err
+	}
+
+	var count int
+	err := s.db.Model(&domain.Hold{}).
+		Where("book_id = ? AND user_id = ? AND status IN (?)", p.BookID, p.UserID,
+			[]domain.HoldStatus{domain.HoldStatusWaiting, domain.HoldStatusReady}).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrExistHold
+	}
+
+	p.Status = domain.HoldStatusWaiting
+	p.BookCopyID = domain.UUID{}
+	p.ReadyAt = nil
+	p.PickupDeadline = nil
+	if err := s.db.Create(p).Error; err != nil {
+		// a concurrent hold of the user may be created after the check
+		if isExistHoldError(err) {
+			return ErrExistHold
+		}
+		return err
+	}
+
+	// the book may be on the shelf already
+	if err := s.assignFreeCopies(p.BookID); err != nil {
+		return err
+	}
+	if err := s.db.Find(p).Error; err != nil {
+		return err
+	}
+	return s.fillPosition(p)
+}
+
+// Cancel implement Cancel for Hold service
+func (s *pgService) Cancel(ctx context.Context, p *domain.Hold) (*domain.Hold, error) {
+	s = s.withContext(ctx)
+	old := domain.Hold{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Find(&old).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !old.IsOpen() {
+		return nil, ErrClosedHold
+	}
+
+	wasReady := old.Status == domain.HoldStatusReady
+	old.Status = domain.HoldStatusCancelled
+	if err := s.db.Save(&old).Error; err != nil {
+		return nil, err
+	}
+	// copy kept for the hold goes to the next one in queue
+	if wasReady {
+		if err := s.assignFreeCopies(old.BookID); err != nil {
+			return nil, err
+		}
+	}
+	return &old, nil
+}
+
+// Find implement Find for Hold service
+func (s *pgService) Find(ctx context.Context, p *domain.Hold) (*domain.Hold, error) {
+	s = s.withContext(ctx)
+	res := domain.Hold{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Find(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &res, s.fillPosition(&res)
+}
+
+// FindAllByUser implement FindAllByUser for Hold service
+func (s *pgService) FindAllByUser(ctx context.Context, p *domain.User) ([]domain.Hold, error) {
+	s = s.withContext(ctx)
+	if err := s.Expire(ctx); err != nil {
+		return nil, err
+	}
+
+	res := []domain.Hold{}
+	if err := s.db.Where("user_id = ?", p.ID).Order("created_at DESC").Find(&res).Error; err != nil {
+		return nil, err
+	}
+	for i := range res {
+		if err := s.fillPosition(&res[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// AssignBookCopy implement AssignBookCopy for Hold service
+func (s *pgService) AssignBookCopy(ctx context.Context, p *domain.BookCopy) error {
+	s = s.withContext(ctx)
+	if err := s.Expire(ctx); err != nil {
+		return err
+	}
+	return s.assignFreeCopies(p.BookID)
+}
+
+// Expire implement Expire for Hold service
+func (s *pgService) Expire(ctx context.Context) error {
+	s = s.withContext(ctx)
+	expired := []domain.Hold{}
+	err := s.db.Where("status = ? AND pickup_deadline <= ?", domain.HoldStatusReady, time.Now()).
+		Find(&expired).Error
+	if err != nil {
+		return err
+	}
+
+	bookIDs := map[domain.UUID]bool{}
+	for _, hold := range expired {
+		err := s.db.Model(&domain.Hold{}).
+			Where("id = ? AND status = ?", hold.ID, domain.HoldStatusReady).
+			Update("status", domain.HoldStatusExpired).Error
+		if err != nil {
+			return err
+		}
+		bookIDs[hold.BookID] = true
+	}
+
+	// expired holds roll their copies to the next ones in queue
+	for bookID := range bookIDs {
+		if err := s.assignFreeCopies(bookID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
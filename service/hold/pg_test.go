@@ -0,0 +1,211 @@
+package hold
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestPGService_Create(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	borrower := domain.User{}
+	err = testDB.Create(&borrower).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+	err = testDB.Create(&domain.LendBook{
+		BookID:     book.ID,
+		BookCopyID: bookCopy.ID,
+		UserID:     borrower.ID,
+		From:       time.Now().Add(-time.Hour),
+		To:         time.Now().AddDate(0, 0, 14),
+	}).Error
+	if err != nil {
+		t.Fatalf("Failed to create lendBook by error %v", err)
+	}
+
+	first := domain.User{}
+	second := domain.User{}
+	for _, u := range []*domain.User{&first, &second} {
+		if err := testDB.Create(u).Error; err != nil {
+			t.Fatalf("Failed to create user by error %v", err)
+		}
+	}
+
+	type args struct {
+		p *domain.Hold
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantPosition int
+		wantErr      error
+	}{
+		{
+			name:         "first in queue",
+			args:         args{&domain.Hold{BookID: book.ID, UserID: first.ID}},
+			wantPosition: 1,
+		},
+		{
+			name:         "second in queue",
+			args:         args{&domain.Hold{BookID: book.ID, UserID: second.ID}},
+			wantPosition: 2,
+		},
+		{
+			name:    "failed create by exist hold",
+			args:    args{&domain.Hold{BookID: book.ID, UserID: first.ID}},
+			wantErr: ErrExistHold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			err := s.Create(context.Background(), tt.args.p)
+			if err != nil && err != tt.wantErr {
+				t.Errorf("pgService.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.wantErr != nil {
+				t.Errorf("pgService.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.args.p.Position != tt.wantPosition {
+				t.Errorf("pgService.Create() position = %v, want %v", tt.args.p.Position, tt.wantPosition)
+			}
+		})
+	}
+}
+
+func TestPGService_AssignBookCopy(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	hold := domain.Hold{BookID: book.ID, UserID: user.ID, Status: domain.HoldStatusWaiting}
+	err = testDB.Create(&hold).Error
+	if err != nil {
+		t.Fatalf("Failed to create hold by error %v", err)
+	}
+
+	s := &pgService{
+		db: testDB,
+	}
+	err = s.AssignBookCopy(context.Background(), &bookCopy)
+	if err != nil {
+		t.Fatalf("pgService.AssignBookCopy() error = %v", err)
+	}
+
+	got := domain.Hold{Model: domain.Model{ID: hold.ID}}
+	err = testDB.Find(&got).Error
+	if err != nil {
+		t.Fatalf("Failed to find hold by error %v", err)
+	}
+	if got.Status != domain.HoldStatusReady || got.BookCopyID != bookCopy.ID || got.PickupDeadline == nil {
+		t.Errorf("pgService.AssignBookCopy() hold = %+v, want ready with copy %v", got, bookCopy.ID)
+	}
+}
+
+func TestPGService_AssignBookCopy_BookedCopy(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	// copy is booked by another loan before pickup deadline of a hold
+	from := time.Now().Add(time.Hour)
+	lendBook := domain.LendBook{BookID: book.ID, BookCopyID: bookCopy.ID, UserID: user.ID, Status: domain.LendBookStatusActive, From: from, To: from.Add(time.Hour)}
+	err = testDB.Create(&lendBook).Error
+	if err != nil {
+		t.Fatalf("Failed to create lendBook by error %v", err)
+	}
+
+	hold := domain.Hold{BookID: book.ID, UserID: user.ID, Status: domain.HoldStatusWaiting}
+	err = testDB.Create(&hold).Error
+	if err != nil {
+		t.Fatalf("Failed to create hold by error %v", err)
+	}
+
+	s := &pgService{
+		db: testDB,
+	}
+	err = s.AssignBookCopy(context.Background(), &bookCopy)
+	if err != nil {
+		t.Fatalf("pgService.AssignBookCopy() error = %v", err)
+	}
+
+	got := domain.Hold{Model: domain.Model{ID: hold.ID}}
+	err = testDB.Find(&got).Error
+	if err != nil {
+		t.Fatalf("Failed to find hold by error %v", err)
+	}
+	if got.Status != domain.HoldStatusWaiting {
+		t.Errorf("pgService.AssignBookCopy() hold status = %v, want %v", got.Status, domain.HoldStatusWaiting)
+	}
+}
@@ -0,0 +1,17 @@
+package hold
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Service interface for project service
+type Service interface {
+	Create(ctx context.Context, p *domain.Hold) error
+	Cancel(ctx context.Context, p *domain.Hold) (*domain.Hold, error)
+	Find(ctx context.Context, p *domain.Hold) (*domain.Hold, error)
+	FindAllByUser(ctx context.Context, p *domain.User) ([]domain.Hold, error)
+	AssignBookCopy(ctx context.Context, p *domain.BookCopy) error
+	Expire(ctx context.Context) error
+}
@@ -2,108 +2,77 @@ package lend_book
 
 import (
 	"net/http"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/apperror"
 )
 
 // Error Declaration
 var (
-	ErrNotFound         = errNotFound{}
-	ErrUnknown          = errUnknown{}
-	ErrRecordNotFound   = errRecordNotFound{}
-	ErrBookIDIsRequired = errBookIDIsRequired{}
-	ErrUserIDIsRequired = errUserIDIsRequired{}
-	ErrFromIsRequired   = errFromIsRequired{}
-	ErrToIsRequired     = errToIsRequired{}
-	ErrBookIDNotExist   = errBookIDNotExist{}
-	ErrUserIDNotExist   = errUserIDNotExist{}
-	ErrLendedBook       = errLendedBook{}
+	ErrNotFound             = apperror.New("lend_book.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown              = apperror.New("lend_book.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound       = apperror.New("lend_book.record_not_found", http.StatusNotFound, "client record not found")
+	ErrBookIDIsRequired     = apperror.New("lend_book.book_id_is_required", http.StatusBadRequest, "ID of book is required")
+	ErrUserIDIsRequired     = apperror.New("lend_book.user_id_is_required", http.StatusBadRequest, "ID of user is required")
+	ErrFromIsRequired       = apperror.New("lend_book.from_is_required", http.StatusBadRequest, "From is required")
+	ErrToIsRequired         = apperror.New("lend_book.to_is_required", http.StatusBadRequest, "To is required")
+	ErrBookIDNotExist       = apperror.New("lend_book.book_id_not_exist", http.StatusNotFound, "ID of book not exist in table books")
+	ErrUserIDNotExist       = apperror.New("lend_book.user_id_not_exist", http.StatusNotFound, "ID of user not exist in table users")
+	ErrLendedBook           = apperror.New("lend_book.lended_book", http.StatusBadRequest, "The book is lended")
+	ErrIDIsRequired         = apperror.New("lend_book.id_is_required", http.StatusBadRequest, "ID of lend book is required")
+	ErrReturnedBook         = apperror.New("lend_book.returned_book", http.StatusBadRequest, "The book is returned")
+	ErrInvalidStatus        = apperror.New("lend_book.invalid_status", http.StatusBadRequest, "Status must be active or lost")
+	ErrBookCopyIDNotExist   = apperror.New("lend_book.book_copy_id_not_exist", http.StatusNotFound, "ID of book copy not exist in table book_copies")
+	ErrBookCopyNotMatchBook = apperror.New("lend_book.book_copy_not_match_book", http.StatusBadRequest, "The book copy is not a copy of the book")
+	ErrLendedBookCopy       = apperror.New("lend_book.lended_book_copy", http.StatusBadRequest, "The book copy is lended in that time")
+	ErrHeldBookCopy         = apperror.New("lend_book.held_book_copy", http.StatusBadRequest, "The book copy is kept for a hold of another user")
+	ErrToBeforeFrom         = apperror.New("lend_book.to_before_from", http.StatusBadRequest, "To must be after From")
+	ErrFromInPast           = apperror.New("lend_book.from_in_past", http.StatusBadRequest, "From can not be in the past")
+	ErrHeldBook             = apperror.New("lend_book.held_book", http.StatusBadRequest, "The book has holds of other users")
+	ErrRenewLimit           = apperror.New("lend_book.renew_limit", http.StatusBadRequest, "The lend book reached maximum number of renewals")
+	ErrLostBook             = apperror.New("lend_book.lost_book", http.StatusBadRequest, "The book is lost")
+	ErrOutstandingBalance   = apperror.New("lend_book.outstanding_balance", http.StatusBadRequest, "The user has outstanding balance greater than allowed")
+	ErrMaxActiveLoans       = apperror.New("lend_book.max_active_loans", http.StatusBadRequest, "The user reached maximum number of active lend books")
+	ErrEmptyBatch           = apperror.New("lend_book.empty_batch", http.StatusBadRequest, "Batch has no lend book")
+	ErrBatchTooLarge        = apperror.New("lend_book.batch_too_large", http.StatusBadRequest, "Batch has too many lend books")
 )
 
-type errNotFound struct{}
-
-func (errNotFound) Error() string {
-	return "record not found"
-}
-func (errNotFound) StatusCode() int {
-	return http.StatusNotFound
+// BatchItemError describe error of a lend book in batch at Index
+type BatchItemError struct {
+	Index   int         `json:"index"`
+	BookID  domain.UUID `json:"book_id"`
+	Code    string      `json:"code"`
+	Message string      `json:"error"`
 }
 
-type errUnknown struct{}
-
-func (errUnknown) Error() string {
-	return "unknown error"
+// BatchError is returned when some lend books of batch fail,
+// it lists the error of each failed lend book
+type BatchError struct {
+	Items []BatchItemError
 }
-func (errUnknown) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errRecordNotFound struct{}
 
-func (errRecordNotFound) Error() string {
-	return "client record not found"
+func (e *BatchError) add(index int, lendBook *domain.LendBook, err error) {
+	e.Items = append(e.Items, BatchItemError{
+		Index:   index,
+		BookID:  lendBook.BookID,
+		Code:    apperror.CodeOf(err, apperror.StatusOf(err)),
+		Message: err.Error(),
+	})
 }
-func (errRecordNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errBookIDIsRequired struct{}
 
-func (errBookIDIsRequired) Error() string {
-	return "ID of book is required"
+func (BatchError) Error() string {
+	return "Some lend books of batch are invalid"
 }
-func (errBookIDIsRequired) StatusCode() int {
+func (BatchError) StatusCode() int {
 	return http.StatusBadRequest
 }
 
-type errUserIDIsRequired struct{}
-
-func (errUserIDIsRequired) Error() string {
-	return "ID of user is required"
-}
-func (errUserIDIsRequired) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errFromIsRequired struct{}
-
-func (errFromIsRequired) Error() string {
-	return "From is required"
-}
-func (errFromIsRequired) StatusCode() int {
-	return http.StatusBadRequest
+// Code return code of error
+func (BatchError) Code() string {
+	return "lend_book.batch_failed"
 }
 
-type errToIsRequired struct{}
-
-func (errToIsRequired) Error() string {
-	return "To is required"
-}
-func (errToIsRequired) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errBookIDNotExist struct{}
-
-func (errBookIDNotExist) Error() string {
-	return "ID of book not exist in table books"
-}
-func (errBookIDNotExist) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errUserIDNotExist struct{}
-
-func (errUserIDNotExist) Error() string {
-	return "ID of user not exist in table users"
-}
-func (errUserIDNotExist) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errLendedBook struct{}
-
-func (errLendedBook) Error() string {
-	return "The book is lended"
-}
-func (errLendedBook) StatusCode() int {
-	return http.StatusBadRequest
+// Details return errors of failed items
+func (e BatchError) Details() interface{} {
+	return e.Items
 }
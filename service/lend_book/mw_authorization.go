@@ -0,0 +1,89 @@
+package lend_book
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits members to lend books for themselves, to
+// read and renew their own lend books, staff to act on lend books of anyone
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, lendBook *domain.LendBook) error {
+	if _, err := auth.RequireSelfOrStaff(ctx, lendBook.UserID); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, lendBook)
+}
+func (mw authorizationMiddleware) CreateBatch(ctx context.Context, lendBooks []*domain.LendBook) error {
+	for _, lendBook := range lendBooks {
+		if _, err := auth.RequireSelfOrStaff(ctx, lendBook.UserID); err != nil {
+			return err
+		}
+	}
+	return mw.Service.CreateBatch(ctx, lendBooks)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error) {
+	p, err := auth.Require(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// members see their own lend books whatever user they filter by
+	if !p.Role.IsStaff() {
+		if q.Filters == nil {
+			q.Filters = map[string][]string{}
+		}
+		q.Filters["user_id"] = []string{p.UserID.String()}
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw authorizationMiddleware) Find(ctx context.Context, lendBook *domain.LendBook) (*domain.LendBook, error) {
+	p, err := auth.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := mw.Service.Find(ctx, lendBook)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Role.IsStaff() && res.UserID != p.UserID {
+		return nil, auth.ErrForbidden
+	}
+	return res, nil
+}
+func (mw authorizationMiddleware) Update(ctx context.Context, lendBook *domain.LendBook) (*domain.LendBook, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Update(ctx, lendBook)
+}
+func (mw authorizationMiddleware) Delete(ctx context.Context, lendBook *domain.LendBook) error {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return mw.Service.Delete(ctx, lendBook)
+}
+func (mw authorizationMiddleware) Return(ctx context.Context, lendBook *domain.LendBook) (*domain.LendBook, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Return(ctx, lendBook)
+}
+func (mw authorizationMiddleware) Renew(ctx context.Context, lendBook *domain.LendBook) (*domain.LendBook, error) {
+	if _, err := mw.Find(ctx, lendBook); err != nil {
+		return nil, err
+	}
+	return mw.Service.Renew(ctx, lendBook)
+}
@@ -0,0 +1,109 @@
+package lend_book
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+func Test_authorizationMiddleware_Create(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CreateFunc: func(_ context.Context, p *domain.LendBook) error {
+			return nil
+		},
+	}
+
+	memberID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	otherID := domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")
+	member := auth.NewContext(context.Background(), &auth.Principal{UserID: memberID, Role: domain.RoleMember})
+	librarian := auth.NewContext(context.Background(), &auth.Principal{UserID: memberID, Role: domain.RoleLibrarian})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		p       *domain.LendBook
+		wantErr error
+	}{
+		{
+			name: "member lends book for own",
+			ctx:  member,
+			p:    &domain.LendBook{UserID: memberID},
+		},
+		{
+			name:    "member lends book for other",
+			ctx:     member,
+			p:       &domain.LendBook{UserID: otherID},
+			wantErr: auth.ErrForbidden,
+		},
+		{
+			name: "librarian lends book for other",
+			ctx:  librarian,
+			p:    &domain.LendBook{UserID: otherID},
+		},
+		{
+			name:    "caller is not authenticated",
+			ctx:     context.Background(),
+			p:       &domain.LendBook{UserID: memberID},
+			wantErr: auth.ErrMissingToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := authorizationMiddleware{
+				Service: serviceMock,
+			}
+			if err := mw.Create(tt.ctx, tt.p); err != tt.wantErr {
+				t.Errorf("authorizationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authorizationMiddleware_Find(t *testing.T) {
+	memberID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	otherID := domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")
+	serviceMock := &ServiceMock{
+		FindFunc: func(_ context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+			return &domain.LendBook{UserID: otherID}, nil
+		},
+	}
+
+	mw := authorizationMiddleware{
+		Service: serviceMock,
+	}
+	member := auth.NewContext(context.Background(), &auth.Principal{UserID: memberID, Role: domain.RoleMember})
+	if _, err := mw.Find(member, &domain.LendBook{}); err != auth.ErrForbidden {
+		t.Errorf("authorizationMiddleware.Find() error = %v, wantErr %v", err, auth.ErrForbidden)
+	}
+	admin := auth.NewContext(context.Background(), &auth.Principal{UserID: memberID, Role: domain.RoleAdmin})
+	if _, err := mw.Find(admin, &domain.LendBook{}); err != nil {
+		t.Errorf("authorizationMiddleware.Find() error = %v, wantErr %v", err, nil)
+	}
+}
+
+func Test_authorizationMiddleware_FindAll(t *testing.T) {
+	var gotQuery *domain.ListQuery
+	serviceMock := &ServiceMock{
+		FindAllFunc: func(_ context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error) {
+			gotQuery = q
+			return nil, &domain.ListMeta{}, nil
+		},
+	}
+
+	memberID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	member := auth.NewContext(context.Background(), &auth.Principal{UserID: memberID, Role: domain.RoleMember})
+	mw := authorizationMiddleware{
+		Service: serviceMock,
+	}
+	q := &domain.ListQuery{Filters: map[string][]string{"user_id": {"dc9076e9-2fda-4019-bd2c-900a8284b9c4"}}}
+	if _, _, err := mw.FindAll(member, q); err != nil {
+		t.Fatalf("authorizationMiddleware.FindAll() error = %v", err)
+	}
+	want := []string{memberID.String()}
+	if !reflect.DeepEqual(gotQuery.Filters["user_id"], want) {
+		t.Errorf("authorizationMiddleware.FindAll() user_id filter = %v, want %v", gotQuery.Filters["user_id"], want)
+	}
+}
@@ -0,0 +1,87 @@
+package lend_book
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "lend_book"),
+			errors:   errors.With("service", "lend_book"),
+			latency:  latency.With("service", "lend_book"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Create(ctx context.Context, lendBook *domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Create", begin, err)
+	}(time.Now())
+	return mw.Service.Create(ctx, lendBook)
+}
+func (mw instrumentingMiddleware) CreateBatch(ctx context.Context, lendBooks []*domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("CreateBatch", begin, err)
+	}(time.Now())
+	return mw.Service.CreateBatch(ctx, lendBooks)
+}
+func (mw instrumentingMiddleware) Update(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Update", begin, err)
+	}(time.Now())
+	return mw.Service.Update(ctx, lendBook)
+}
+func (mw instrumentingMiddleware) Find(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Find", begin, err)
+	}(time.Now())
+	return mw.Service.Find(ctx, lendBook)
+}
+func (mw instrumentingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.LendBook, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAll", begin, err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw instrumentingMiddleware) Delete(ctx context.Context, lendBook *domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Delete", begin, err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, lendBook)
+}
+func (mw instrumentingMiddleware) Return(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Return", begin, err)
+	}(time.Now())
+	return mw.Service.Return(ctx, lendBook)
+}
+func (mw instrumentingMiddleware) Renew(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Renew", begin, err)
+	}(time.Now())
+	return mw.Service.Renew(ctx, lendBook)
+}
@@ -0,0 +1,75 @@
+package lend_book
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type loggingMiddleware struct {
+	Service
+	logger log.Logger
+}
+
+// LoggingMiddleware log every call of service with its duration and error
+func LoggingMiddleware(logger log.Logger) func(Service) Service {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			Service: next,
+			logger:  log.With(logger, "service", "lend_book"),
+		}
+	}
+}
+
+func (mw loggingMiddleware) Create(ctx context.Context, lendBook *domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "lend_book_id", lendBook.ID, "book_id", lendBook.BookID,
+			"book_copy_id", lendBook.BookCopyID, "user_id", lendBook.UserID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Create(ctx, lendBook)
+}
+func (mw loggingMiddleware) CreateBatch(ctx context.Context, lendBooks []*domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "CreateBatch", "count", len(lendBooks), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.CreateBatch(ctx, lendBooks)
+}
+func (mw loggingMiddleware) Update(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Update", "lend_book_id", lendBook.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Update(ctx, lendBook)
+}
+func (mw loggingMiddleware) Find(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "lend_book_id", lendBook.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Find(ctx, lendBook)
+}
+func (mw loggingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.LendBook, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "FindAll", "count", len(res), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw loggingMiddleware) Delete(ctx context.Context, lendBook *domain.LendBook) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "lend_book_id", lendBook.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, lendBook)
+}
+func (mw loggingMiddleware) Return(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Return", "lend_book_id", lendBook.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Return(ctx, lendBook)
+}
+func (mw loggingMiddleware) Renew(ctx context.Context, lendBook *domain.LendBook) (res *domain.LendBook, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Renew", "lend_book_id", lendBook.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Renew(ctx, lendBook)
+}
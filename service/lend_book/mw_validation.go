@@ -2,11 +2,20 @@ package lend_book
 
 import (
 	"context"
+	"time"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
 )
 
-// Declare Regex
+// fromPastTolerance is how far in the past From of a new lend book may be,
+// it allows a lend book to be recorded a bit after the book is given
+const fromPastTolerance = 24 * time.Hour
+
+// maxBatchSize is the greatest number of lend books created in one batch
+const maxBatchSize = 20
+
 type validationMiddleware struct {
 	Service
 }
@@ -20,33 +29,110 @@ func ValidationMiddleware() func(Service) Service {
 	}
 }
 
-func (mw validationMiddleware) Create(ctx context.Context, lend_book *domain.LendBook) (err error) {
-	if lend_book.BookID.IsZero() {
-		return ErrBookIDIsRequired
+// validateCreate check lend_book has what is needed to be created
+func validateCreate(lend_book *domain.LendBook) error {
+	errs := validation.Errors{}
+	// book copy is picked by service when only book is given
+	if lend_book.BookID.IsZero() && lend_book.BookCopyID.IsZero() {
+		errs.Add("book_id", validation.CodeRequired, ErrBookIDIsRequired)
 	}
 
 	if lend_book.UserID.IsZero() {
-		return ErrUserIDIsRequired
+		errs.Add("user_id", validation.CodeRequired, ErrUserIDIsRequired)
 	}
+	// dates are checked against From only when it is given
 	if lend_book.From.IsZero() {
-		return ErrFromIsRequired
+		errs.Add("from", validation.CodeRequired, ErrFromIsRequired)
+		return errs.Err()
+	}
+	// To is given by lending policy when it is omitted
+	if !lend_book.To.IsZero() && !lend_book.To.After(lend_book.From) {
+		errs.Add("to", validation.CodeOutOfRange, ErrToBeforeFrom)
 	}
-	if lend_book.To.IsZero() {
-		return ErrToIsRequired
+	if lend_book.From.Before(time.Now().Add(-fromPastTolerance)) {
+		errs.Add("from", validation.CodeOutOfRange, ErrFromInPast)
 	}
+	return errs.Err()
+}
 
+func (mw validationMiddleware) Create(ctx context.Context, lend_book *domain.LendBook) (err error) {
+	if err := validateCreate(lend_book); err != nil {
+		return err
+	}
 	return mw.Service.Create(ctx, lend_book)
 }
-func (mw validationMiddleware) FindAll(ctx context.Context) ([]domain.LendBook, error) {
-	return mw.Service.FindAll(ctx)
+func (mw validationMiddleware) CreateBatch(ctx context.Context, lend_books []*domain.LendBook) (err error) {
+	if len(lend_books) == 0 {
+		return ErrEmptyBatch
+	}
+	if len(lend_books) > maxBatchSize {
+		return ErrBatchTooLarge
+	}
+
+	batchErr := BatchError{}
+	for i, lend_book := range lend_books {
+		if err := validateCreate(lend_book); err != nil {
+			batchErr.add(i, lend_book, err)
+		}
+	}
+	if len(batchErr.Items) > 0 {
+		return batchErr
+	}
+
+	return mw.Service.CreateBatch(ctx, lend_books)
+}
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "user_id", "book_id", "book_copy_id", "status"); err != nil {
+		return nil, nil, err
+	}
+	for _, name := range []string{"user_id", "book_id", "book_copy_id"} {
+		if _, err := list.FilterUUID(q, name); err != nil {
+			return nil, nil, err
+		}
+	}
+	switch domain.LendBookStatus(q.Filter("status")) {
+	case "", domain.LendBookStatusActive, domain.LendBookStatusReturned,
+		domain.LendBookStatusOverdue, domain.LendBookStatusLost:
+	default:
+		return nil, nil, ErrInvalidStatus
+	}
+	return mw.Service.FindAll(ctx, q)
 }
 func (mw validationMiddleware) Find(ctx context.Context, lend_book *domain.LendBook) (*domain.LendBook, error) {
 	return mw.Service.Find(ctx, lend_book)
 }
 
 func (mw validationMiddleware) Update(ctx context.Context, lend_book *domain.LendBook) (*domain.LendBook, error) {
+	errs := validation.Errors{}
+	// returned and overdue are reached by returning book or by time, not by update
+	if lend_book.Status != "" &&
+		lend_book.Status != domain.LendBookStatusActive &&
+		lend_book.Status != domain.LendBookStatusLost {
+		errs.Add("status", validation.CodeInvalid, ErrInvalidStatus)
+	}
+	if !lend_book.From.IsZero() && !lend_book.To.IsZero() && !lend_book.To.After(lend_book.From) {
+		errs.Add("to", validation.CodeOutOfRange, ErrToBeforeFrom)
+	}
+	if !lend_book.From.IsZero() && lend_book.From.Before(time.Now().Add(-fromPastTolerance)) {
+		errs.Add("from", validation.CodeOutOfRange, ErrFromInPast)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
 	return mw.Service.Update(ctx, lend_book)
 }
 func (mw validationMiddleware) Delete(ctx context.Context, lend_book *domain.LendBook) error {
 	return mw.Service.Delete(ctx, lend_book)
 }
+func (mw validationMiddleware) Return(ctx context.Context, lend_book *domain.LendBook) (*domain.LendBook, error) {
+	if lend_book.ID.IsZero() {
+		return nil, ErrIDIsRequired
+	}
+	return mw.Service.Return(ctx, lend_book)
+}
+func (mw validationMiddleware) Renew(ctx context.Context, lend_book *domain.LendBook) (*domain.LendBook, error) {
+	if lend_book.ID.IsZero() {
+		return nil, ErrIDIsRequired
+	}
+	return mw.Service.Renew(ctx, lend_book)
+}
@@ -37,15 +37,57 @@ func Test_validationMiddleware_Update(t *testing.T) {
 				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
 				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
 				From:   ti,
-				To:     ti,
+				To:     ti.Add(time.Hour),
 			}},
 			wantOutput: &domain.LendBook{
 				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
 				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
 				From:   ti,
-				To:     ti,
+				To:     ti.Add(time.Hour),
 			},
 		},
+		{
+			name: "valid lendBook by lost status",
+			args: args{&domain.LendBook{
+				Status: domain.LendBookStatusLost,
+			}},
+			wantOutput: &domain.LendBook{
+				Status: domain.LendBookStatusLost,
+			},
+		},
+		{
+			name: "invalid lendBook by returned status",
+			args: args{&domain.LendBook{
+				Status: domain.LendBookStatusReturned,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendBook by to before from",
+			args: args{&domain.LendBook{
+				From: ti,
+				To:   ti.Add(-time.Hour),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendBook by from in past",
+			args: args{&domain.LendBook{
+				From: ti.Add(-2 * fromPastTolerance),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendBook by unknown status",
+			args: args{&domain.LendBook{
+				Status: "borrowed",
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -101,7 +143,16 @@ func Test_validationMiddleware_Create(t *testing.T) {
 				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
 				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
 				From:   time.Now(),
-				To:     time.Now(),
+				To:     time.Now().Add(time.Hour),
+			}},
+		},
+		{
+			name: "valid lendBook by bookCopyID without bookID",
+			args: args{&domain.LendBook{
+				BookCopyID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-922a8284b9c4"),
+				UserID:     domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+				From:       time.Now(),
+				To:         time.Now().Add(time.Hour),
 			}},
 		},
 		{
@@ -109,7 +160,7 @@ func Test_validationMiddleware_Create(t *testing.T) {
 			args: args{&domain.LendBook{
 				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
 				From:   time.Now(),
-				To:     time.Now(),
+				To:     time.Now().Add(time.Hour),
 			}},
 			wantErr:         true,
 			errorStatusCode: http.StatusBadRequest,
@@ -119,7 +170,7 @@ func Test_validationMiddleware_Create(t *testing.T) {
 			args: args{&domain.LendBook{
 				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
 				From:   time.Now(),
-				To:     time.Now(),
+				To:     time.Now().Add(time.Hour),
 			}},
 			wantErr:         true,
 			errorStatusCode: http.StatusBadRequest,
@@ -129,21 +180,50 @@ func Test_validationMiddleware_Create(t *testing.T) {
 			args: args{&domain.LendBook{
 				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
 				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
-				To:     time.Now(),
+				To:     time.Now().Add(time.Hour),
 			}},
 			wantErr:         true,
 			errorStatusCode: http.StatusBadRequest,
 		},
 		{
-			name: "invalid lendBook by missing now",
+			name: "valid lendBook by missing to",
 			args: args{&domain.LendBook{
 				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
 				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
 				From:   time.Now(),
 			}},
+		},
+		{
+			name: "invalid lendBook by to before from",
+			args: args{&domain.LendBook{
+				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+				From:   time.Now(),
+				To:     time.Now().Add(-time.Hour),
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendBook by from years in the past",
+			args: args{&domain.LendBook{
+				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+				From:   time.Now().AddDate(-3, 0, 0),
+				To:     time.Now().AddDate(-3, 0, 14),
+			}},
 			wantErr:         true,
 			errorStatusCode: http.StatusBadRequest,
 		},
+		{
+			name: "valid lendBook by future booking",
+			args: args{&domain.LendBook{
+				BookID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				UserID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4"),
+				From:   time.Now().AddDate(0, 1, 0),
+				To:     time.Now().AddDate(0, 1, 14),
+			}},
+		},
 		{
 			name:            "invalid lendBook by missing attribute",
 			args:            args{&domain.LendBook{}},
@@ -178,6 +258,94 @@ func Test_validationMiddleware_Create(t *testing.T) {
 	}
 }
 
+func Test_validationMiddleware_CreateBatch(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CreateBatchFunc: func(_ context.Context, p []*domain.LendBook) error {
+			return nil
+		},
+	}
+
+	userID := domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-911a8284b9c4")
+	newLendBook := func(bookID string, from time.Time) *domain.LendBook {
+		return &domain.LendBook{
+			BookID: domain.MustGetUUIDFromString(bookID),
+			UserID: userID,
+			From:   from,
+		}
+	}
+	tooLarge := []*domain.LendBook{}
+	for i := 0; i <= maxBatchSize; i++ {
+		tooLarge = append(tooLarge, newLendBook("dc9076e9-2fda-4019-bd2c-900a8284b9c4", time.Now()))
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p []*domain.LendBook
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantErr        bool
+		wantItemErrors []int
+	}{
+		{
+			name: "valid batch",
+			args: args{[]*domain.LendBook{
+				newLendBook("dc9076e9-2fda-4019-bd2c-900a8284b9c4", time.Now()),
+				newLendBook("dc9076e9-2fda-4019-bd2c-900a8284b9c5", time.Now()),
+			}},
+		},
+		{
+			name:    "invalid batch by no lendBook",
+			args:    args{[]*domain.LendBook{}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid batch by too many lendBooks",
+			args:    args{tooLarge},
+			wantErr: true,
+		},
+		{
+			name: "invalid batch by invalid lendBooks",
+			args: args{[]*domain.LendBook{
+				newLendBook("dc9076e9-2fda-4019-bd2c-900a8284b9c4", time.Now()),
+				{UserID: userID, From: time.Now()},
+				newLendBook("dc9076e9-2fda-4019-bd2c-900a8284b9c5", time.Now().AddDate(-3, 0, 0)),
+			}},
+			wantErr:        true,
+			wantItemErrors: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			err := mw.CreateBatch(defaultCtx, tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validationMiddleware.CreateBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantItemErrors == nil {
+				return
+			}
+
+			batchErr, ok := err.(BatchError)
+			if !ok {
+				t.Errorf("validationMiddleware.CreateBatch() error %v is not BatchError", err)
+				return
+			}
+			gotItemErrors := []int{}
+			for _, item := range batchErr.Items {
+				gotItemErrors = append(gotItemErrors, item.Index)
+			}
+			if !reflect.DeepEqual(gotItemErrors, tt.wantItemErrors) {
+				t.Errorf("validationMiddleware.CreateBatch() failed items = %v, want %v", gotItemErrors, tt.wantItemErrors)
+			}
+		})
+	}
+}
+
 func Test_validationMiddleware_Find(t *testing.T) {
 	type fields struct {
 		Service Service
@@ -218,6 +386,7 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 	}
 	type args struct {
 		ctx context.Context
+		q   *domain.ListQuery
 	}
 	tests := []struct {
 		name       string
@@ -233,7 +402,7 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 			mw := validationMiddleware{
 				Service: tt.fields.Service,
 			}
-			gotOutput, err := mw.FindAll(tt.args.ctx)
+			gotOutput, _, err := mw.FindAll(tt.args.ctx, tt.args.q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validationMiddleware.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -272,3 +441,123 @@ func Test_validationMiddleware_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_validationMiddleware_Return(t *testing.T) {
+	serviceMock := &ServiceMock{
+		ReturnFunc: func(_ context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+			return p, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.LendBook
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid lendBook",
+			args: args{&domain.LendBook{
+				Model: domain.Model{ID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")},
+			}},
+		},
+		{
+			name:            "invalid lendBook by missing id",
+			args:            args{&domain.LendBook{}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			_, err := mw.Return(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Return() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Return() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Return() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Return() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validationMiddleware_Renew(t *testing.T) {
+	serviceMock := &ServiceMock{
+		RenewFunc: func(_ context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+			return p, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.LendBook
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid lendBook",
+			args: args{&domain.LendBook{
+				Model: domain.Model{ID: domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")},
+			}},
+		},
+		{
+			name:            "invalid lendBook by missing id",
+			args:            args{&domain.LendBook{}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			_, err := mw.Renew(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Renew() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Renew() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Renew() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Renew() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
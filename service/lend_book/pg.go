@@ -2,49 +2,361 @@ package lend_book
 
 import (
 	"context"
+	"time"
 
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
 
+	"github.com/phungvandat/example-go/config/database/pg"
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/fine"
+	"github.com/phungvandat/example-go/service/hold"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
 )
 
 // pgService implmenter for LendBook serivce in postgres
 type pgService struct {
 	db *gorm.DB
+	// maxOutstandingBalance is the greatest balance of user who can lend books
+	maxOutstandingBalance int64
 }
 
-// NewPGService create new PGService
-func NewPGService(db *gorm.DB) Service {
+// NewPGService create new PGService, a user whose balance is greater than
+// maxOutstandingBalance can not lend books
+func NewPGService(db *gorm.DB, maxOutstandingBalance int64) Service {
 	return &pgService{
-		db: db,
+		db:                    db,
+		maxOutstandingBalance: maxOutstandingBalance,
 	}
 }
 
-// Create implement Create for LendBook service
-func (s *pgService) Create(_ context.Context, p *domain.LendBook) error {
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// overlapLendBookCond is condition of lend book l which is not returned and holds
+// its book copy in range [from, to), an overdue lend book holds its copy until now
+const overlapLendBookCond = `l.returned_at IS NULL AND l.deleted_at IS NULL
+	AND tstzrange(l."from", GREATEST(l."to", now())) && tstzrange(?, ?)`
+
+// heldBookCopyCond is condition of a book copy which is kept for
+// a ready hold of another user than the given one
+const heldBookCopyCond = `EXISTS (
+	SELECT 1 FROM holds h
+	WHERE h.book_copy_id = book_copies.id AND h.status = 'ready' AND h.deleted_at IS NULL
+	AND h.pickup_deadline > now() AND h.user_id IS DISTINCT FROM ?
+)`
+
+// resolveLendingPolicy find policy of books in category for users of tier, a policy
+// of the category is more specific than a policy of the tier, a policy of both is
+// the most specific one. The default policy is used when no policy matches.
+func (s *pgService) resolveLendingPolicy(categoryID domain.UUID, tier domain.MembershipTier) (*domain.LendingPolicy, error) {
+	policy := domain.LendingPolicy{}
+	err := s.db.Where("category_id = ? OR category_id IS NULL", categoryID).
+		Where("tier = ? OR tier = ''", tier).
+		Order("category_id IS NULL, tier = ''").
+		First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return domain.DefaultLendingPolicy(), nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// lendingPolicyOf return policy which was resolved for lend book p,
+// it is used even though the policy is deleted later
+func (s *pgService) lendingPolicyOf(p *domain.LendBook) (*domain.LendingPolicy, error) {
+	if p.LendingPolicyID.IsZero() {
+		return domain.DefaultLendingPolicy(), nil
+	}
+	policy := domain.LendingPolicy{}
+	if err := s.db.Unscoped().Where("id = ?", p.LendingPolicyID).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// isLendedBookCopy check book copy has a lend book which is not returned yet
+// in range of p, the lend book with exceptID is ignored
+func (s *pgService) isLendedBookCopy(bookCopyID domain.UUID, p *domain.LendBook, exceptID domain.UUID) (bool, error) {
+	var count int
+	q := s.db.Table("lend_books l").
+		Where("l.book_copy_id = ?", bookCopyID).
+		Where(overlapLendBookCond, p.From, p.To)
+	if !exceptID.IsZero() {
+		q = q.Where("l.id <> ?", exceptID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// isOverlapError check err is raised by exclusion constraint of lend books,
+// which guarantees a book copy is not lended twice in the same time
+func isOverlapError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "exclusion_violation"
+}
+
+// findBookCopy find book copy for lend book p: the copy of BookCopyID if it is given,
+// otherwise a copy of BookID which is available in range of p. When mustAvailable
+// is false the copy is not checked to be free, the lend book with exceptID is ignored
+// when checking. The copy is locked, so s must run in a transaction and concurrent
+// lends of the copy wait until it ends before they check it.
+func (s *pgService) findBookCopy(p *domain.LendBook, exceptID domain.UUID, mustAvailable bool) (*domain.BookCopy, error) {
+	bookCopy := domain.BookCopy{}
+	if !p.BookCopyID.IsZero() {
+		err := s.db.Set("gorm:query_option", "FOR UPDATE").
+			Where("id = ?", p.BookCopyID).
+			Find(&bookCopy).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, ErrBookCopyIDNotExist
+			}
+			return nil, err
+		}
+		if !p.BookID.IsZero() && p.BookID != bookCopy.BookID {
+			return nil, ErrBookCopyNotMatchBook
+		}
+		if mustAvailable {
+			lended, err := s.isLendedBookCopy(bookCopy.ID, p, exceptID)
+			if err != nil {
+				return nil, err
+			}
+			if lended {
+				return nil, ErrLendedBookCopy
+			}
+
+			var held int
+			err = s.db.Model(&domain.BookCopy{}).
+				Where("id = ?", bookCopy.ID).
+				Where(heldBookCopyCond, p.UserID).
+				Count(&held).Error
+			if err != nil {
+				return nil, err
+			}
+			if held > 0 {
+				return nil, ErrHeldBookCopy
+			}
+		}
+		return &bookCopy, nil
+	}
+
 	var errExistBoID = s.db.Where("id = ?", p.BookID).Find(&domain.Book{}).Error
 	if errExistBoID != nil {
 		if errExistBoID == gorm.ErrRecordNotFound {
-			return ErrBookIDNotExist
+			return nil, ErrBookIDNotExist
 		}
-		return errExistBoID
+		return nil, errExistBoID
+	}
+	q := s.db.Where("book_id = ?", p.BookID)
+	if mustAvailable {
+		q = q.Where(`NOT EXISTS (
+			SELECT 1 FROM lend_books l
+			WHERE l.book_copy_id = book_copies.id AND l.id IS DISTINCT FROM ?
+			AND `+overlapLendBookCond+`
+		)`, exceptID, p.From, p.To).
+			Where("NOT "+heldBookCopyCond, p.UserID).
+			// a copy kept for ready hold of the user is given first
+			Order(gorm.Expr(`EXISTS (
+				SELECT 1 FROM holds h
+				WHERE h.book_copy_id = book_copies.id AND h.status = 'ready'
+				AND h.deleted_at IS NULL AND h.user_id = ?
+			) DESC`, p.UserID))
 	}
-	var errLendedBo = s.db.Where("book_id  = ?", p.BookID).Find(&domain.LendBook{}).Error
-	if errLendedBo == nil {
-		return ErrLendedBook
+	err := q.Set("gorm:query_option", "FOR UPDATE").Order("acquired_at").First(&bookCopy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrLendedBook
+		}
+		return nil, err
 	}
-	var errExistUsID = s.db.Where("id = ?", p.UserID).Find(&domain.User{}).Error
+	if mustAvailable {
+		// a lend book committed while the copy was waited for is seen only now
+		lended, err := s.isLendedBookCopy(bookCopy.ID, p, exceptID)
+		if err != nil {
+			return nil, err
+		}
+		if lended {
+			return nil, ErrLendedBook
+		}
+	}
+	return &bookCopy, nil
+}
+
+// withTx return copy of s which runs in transaction tx
+func (s *pgService) withTx(tx *gorm.DB) *pgService {
+	c := *s
+	c.db = tx
+	return &c
+}
+
+// Create implement Create for LendBook service
+func (s *pgService) Create(ctx context.Context, p *domain.LendBook) error {
+	s = s.withContext(ctx)
+	return pg.Transaction(s.db, func(tx *gorm.DB) error {
+		return s.withTx(tx).create(ctx, p)
+	})
+}
+
+// create lend book p, s must run in a transaction as user is locked until
+// it ends
+func (s *pgService) create(ctx context.Context, p *domain.LendBook) error {
+	// user is locked so concurrent lends and payments see the same balance
+	user := domain.User{}
+	var errExistUsID = s.db.Set("gorm:query_option", "FOR UPDATE").
+		Where("id = ?", p.UserID).
+		Find(&user).Error
 	if errExistUsID != nil {
 		if errExistUsID == gorm.ErrRecordNotFound {
 			return ErrUserIDNotExist
 		}
 		return errExistUsID
 	}
-	return s.db.Create(p).Error
+
+	// fines of user are charged before balance is checked, they are rolled
+	// back with the lend book when it fails
+	fines := fine.NewPGService(s.db)
+	if err := fines.Accrue(ctx, &user); err != nil {
+		return err
+	}
+	balance, err := fines.Balance(ctx, &user)
+	if err != nil {
+		return err
+	}
+	if balance.Amount > s.maxOutstandingBalance {
+		return ErrOutstandingBalance
+	}
+
+	// book is needed to resolve policy, which gives To when it is omitted
+	bookID := p.BookID
+	if bookID.IsZero() {
+		bookCopy := domain.BookCopy{}
+		if err := s.db.Where("id = ?", p.BookCopyID).Find(&bookCopy).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrBookCopyIDNotExist
+			}
+			return err
+		}
+		bookID = bookCopy.BookID
+	}
+	book := domain.Book{}
+	if err := s.db.Where("id = ?", bookID).Find(&book).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrBookIDNotExist
+		}
+		return err
+	}
+	policy, err := s.resolveLendingPolicy(book.CategoryID, user.Tier)
+	if err != nil {
+		return err
+	}
+	if p.To.IsZero() {
+		p.To = p.From.Add(policy.LoanPeriod())
+	}
+
+	var activeLoans int
+	err = s.db.Model(&domain.LendBook{}).
+		Where("user_id = ? AND returned_at IS NULL", p.UserID).
+		Count(&activeLoans).Error
+	if err != nil {
+		return err
+	}
+	if activeLoans >= policy.MaxActiveLoans {
+		return ErrMaxActiveLoans
+	}
+
+	bookCopy, err := s.findBookCopy(p, domain.UUID{}, true)
+	if err != nil {
+		return err
+	}
+	p.BookID = bookCopy.BookID
+	p.BookCopyID = bookCopy.ID
+	p.LendingPolicyID = policy.ID
+
+	p.Status = domain.LendBookStatusActive
+	p.ReturnedAt = nil
+	if err := s.db.Create(p).Error; err != nil {
+		if isOverlapError(err) {
+			return ErrLendedBookCopy
+		}
+		return err
+	}
+	p.Status = p.CurrentStatus(time.Now())
+
+	// user got the book, so holds of the user on it are done
+	return s.db.Model(&domain.Hold{}).
+		Where("book_id = ? AND user_id = ? AND status IN (?)", p.BookID, p.UserID,
+			[]domain.HoldStatus{domain.HoldStatusWaiting, domain.HoldStatusReady}).
+		Update("status", domain.HoldStatusFulfilled).Error
+}
+
+// CreateBatch implement CreateBatch for LendBook service, lend books are
+// created in one transaction so none of them is created when one fails.
+// Each lend book runs in a savepoint, a lend book rejected by a database
+// constraint would abort the transaction otherwise, so every rejected lend
+// book of the batch is reported in BatchError by its index
+func (s *pgService) CreateBatch(ctx context.Context, p []*domain.LendBook) error {
+	s = s.withContext(ctx)
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	txService := s.withTx(tx)
+
+	batchErr := BatchError{}
+	for i, lendBook := range p {
+		// a failed lend book is rolled back alone, so the next ones are still checked
+		if err := tx.Exec("SAVEPOINT lend_book_batch_item").Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		err := txService.create(ctx, lendBook)
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(interface{ StatusCode() int }); !ok {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Exec("ROLLBACK TO SAVEPOINT lend_book_batch_item").Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		batchErr.add(i, lendBook, err)
+	}
+	if len(batchErr.Items) > 0 {
+		tx.Rollback()
+		return batchErr
+	}
+	return tx.Commit().Error
 }
 
 // Update implement Update for LendBook service
-func (s *pgService) Update(_ context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+func (s *pgService) Update(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+	s = s.withContext(ctx)
+	var res *domain.LendBook
+	err := pg.Transaction(s.db, func(tx *gorm.DB) error {
+		var err error
+		res, err = s.withTx(tx).update(p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// update lend book p, s must run in a transaction as its book copy is locked
+// until it ends
+func (s *pgService) update(p *domain.LendBook) (*domain.LendBook, error) {
 	old := domain.LendBook{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -52,22 +364,6 @@ func (s *pgService) Update(_ context.Context, p *domain.LendBook) (*domain.LendB
 		}
 		return nil, err
 	}
-	if !p.BookID.IsZero() {
-		if p.BookID != old.BookID {
-			var errExistBoID = s.db.Where("id = ?", p.BookID).Find(&domain.Book{}).Error
-			if errExistBoID != nil {
-				if errExistBoID == gorm.ErrRecordNotFound {
-					return nil, ErrBookIDNotExist
-				}
-				return nil, errExistBoID
-			}
-			var errLendedBo = s.db.Where("book_id  = ?", p.BookID).Find(&domain.LendBook{}).Error
-			if errLendedBo == nil {
-				return nil, ErrLendedBook
-			}
-			old.BookID = p.BookID
-		}
-	}
 	if !p.UserID.IsZero() {
 		if p.UserID != old.UserID {
 			var errExistUsID = s.db.Where("id = ?", p.UserID).Find(&domain.User{}).Error
@@ -86,11 +382,45 @@ func (s *pgService) Update(_ context.Context, p *domain.LendBook) (*domain.LendB
 	if !p.To.IsZero() {
 		old.To = p.To
 	}
-	return &old, s.db.Save(&old).Error
+	if !old.To.After(old.From) {
+		return nil, ErrToBeforeFrom
+	}
+	changedCopy := !p.BookCopyID.IsZero() && p.BookCopyID != old.BookCopyID
+	changedBook := !p.BookID.IsZero() && p.BookID != old.BookID
+	if changedCopy || changedBook {
+		// only a lend book which is not returned holds its book copy
+		bookCopy, err := s.findBookCopy(&domain.LendBook{
+			BookID:     p.BookID,
+			BookCopyID: p.BookCopyID,
+			UserID:     old.UserID,
+			From:       old.From,
+			To:         old.To,
+		}, old.ID, !old.IsReturned())
+		if err != nil {
+			return nil, err
+		}
+		old.BookID = bookCopy.BookID
+		old.BookCopyID = bookCopy.ID
+	}
+	if p.Status != "" {
+		if old.IsReturned() {
+			return nil, ErrReturnedBook
+		}
+		old.Status = p.Status
+	}
+	if err := s.db.Save(&old).Error; err != nil {
+		if isOverlapError(err) {
+			return nil, ErrLendedBookCopy
+		}
+		return nil, err
+	}
+	old.Status = old.CurrentStatus(time.Now())
+	return &old, nil
 }
 
 // Find implement Find for LendBook service
-func (s *pgService) Find(_ context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+func (s *pgService) Find(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+	s = s.withContext(ctx)
 	res := p
 	if err := s.db.Find(&res).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -98,18 +428,52 @@ func (s *pgService) Find(_ context.Context, p *domain.LendBook) (*domain.LendBoo
 		}
 		return nil, err
 	}
+	res.Status = res.CurrentStatus(time.Now())
 
 	return res, nil
 }
 
 // FindAll implement FindAll for LendBook service
-func (s *pgService) FindAll(_ context.Context) ([]domain.LendBook, error) {
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	for _, name := range []string{"user_id", "book_id", "book_copy_id"} {
+		id, err := list.FilterUUID(q, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !id.IsZero() {
+			db = db.Where(name+" = ?", id)
+		}
+	}
+
+	now := time.Now()
+	// status is stored only for lost books, the others follow CurrentStatus
+	switch domain.LendBookStatus(q.Filter("status")) {
+	case domain.LendBookStatusReturned:
+		db = db.Where("returned_at IS NOT NULL")
+	case domain.LendBookStatusLost:
+		db = db.Where("returned_at IS NULL AND status = ?", domain.LendBookStatusLost)
+	case domain.LendBookStatusOverdue:
+		db = db.Where(`returned_at IS NULL AND status <> ? AND "to" < ?`, domain.LendBookStatusLost, now)
+	case domain.LendBookStatusActive:
+		db = db.Where(`returned_at IS NULL AND status <> ? AND "to" >= ?`, domain.LendBookStatusLost, now)
+	}
+
 	res := []domain.LendBook{}
-	return res, s.db.Find(&res).Error
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range res {
+		res[i].Status = res[i].CurrentStatus(now)
+	}
+	return res, meta, nil
 }
 
 // Delete implement Delete for LendBook service
-func (s *pgService) Delete(_ context.Context, p *domain.LendBook) error {
+func (s *pgService) Delete(ctx context.Context, p *domain.LendBook) error {
+	s = s.withContext(ctx)
 	old := domain.LendBook{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -119,3 +483,113 @@ func (s *pgService) Delete(_ context.Context, p *domain.LendBook) error {
 	}
 	return s.db.Delete(old).Error
 }
+
+// Return implement Return for LendBook service, the returned copy goes to
+// the next hold in queue of the book in the same transaction
+func (s *pgService) Return(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+	s = s.withContext(ctx)
+	old := domain.LendBook{Model: domain.Model{ID: p.ID}}
+	err := pg.Transaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Set("gorm:query_option", "FOR UPDATE").Find(&old).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrNotFound
+			}
+			return err
+		}
+		if old.IsReturned() {
+			return ErrReturnedBook
+		}
+
+		now := time.Now()
+		old.ReturnedAt = &now
+		old.Status = domain.LendBookStatusReturned
+		if err := tx.Save(&old).Error; err != nil {
+			return err
+		}
+		// a book returned late is charged up to ReturnedAt at once
+		if err := fine.NewPGService(tx).AccrueLendBook(ctx, &old); err != nil {
+			return err
+		}
+		return hold.NewPGService(tx).AssignBookCopy(ctx, &domain.BookCopy{
+			Model:  domain.Model{ID: old.BookCopyID},
+			BookID: old.BookID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &old, nil
+}
+
+// Renew implement Renew for LendBook service
+func (s *pgService) Renew(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+	s = s.withContext(ctx)
+	var res *domain.LendBook
+	err := pg.Transaction(s.db, func(tx *gorm.DB) error {
+		var err error
+		res, err = s.withTx(tx).renew(p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// renew lend book p, s must run in a transaction as the lend book is locked
+// until it ends, so concurrent renews can not pass the limit
+func (s *pgService) renew(p *domain.LendBook) (*domain.LendBook, error) {
+	old := domain.LendBook{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Set("gorm:query_option", "FOR UPDATE").Find(&old).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if old.IsReturned() {
+		return nil, ErrReturnedBook
+	}
+	if old.Status == domain.LendBookStatusLost {
+		return nil, ErrLostBook
+	}
+	policy, err := s.lendingPolicyOf(&old)
+	if err != nil {
+		return nil, err
+	}
+	if old.RenewCount >= policy.MaxRenewals {
+		return nil, ErrRenewLimit
+	}
+
+	// other users waiting for the book go first
+	var held int
+	err = s.db.Model(&domain.Hold{}).
+		Where("book_id = ? AND user_id <> ? AND status IN (?)", old.BookID, old.UserID,
+			[]domain.HoldStatus{domain.HoldStatusWaiting, domain.HoldStatusReady}).
+		Count(&held).Error
+	if err != nil {
+		return nil, err
+	}
+	if held > 0 {
+		return nil, ErrHeldBook
+	}
+
+	renewed := old
+	renewed.To = old.To.Add(policy.LoanPeriod())
+	lended, err := s.isLendedBookCopy(old.BookCopyID, &renewed, old.ID)
+	if err != nil {
+		return nil, err
+	}
+	if lended {
+		return nil, ErrLendedBookCopy
+	}
+
+	renewed.RenewCount++
+	if err := s.db.Save(&renewed).Error; err != nil {
+		if isOverlapError(err) {
+			return nil, ErrLendedBookCopy
+		}
+		return nil, err
+	}
+	renewed.Status = renewed.CurrentStatus(time.Now())
+	return &renewed, nil
+}
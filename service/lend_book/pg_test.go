@@ -12,6 +12,10 @@ import (
 	"github.com/phungvandat/example-go/domain"
 )
 
+// testMaxOutstandingBalance is the greatest balance of user who can lend
+// books in tests
+const testMaxOutstandingBalance = 1000
+
 func TestPGService_Create(t *testing.T) {
 	t.Parallel()
 	testDB, _, cleanup := testutil.CreateTestDatabase(t)
@@ -27,12 +31,33 @@ func TestPGService_Create(t *testing.T) {
 		t.Fatalf("Failed to create book by error %v", err)
 	}
 
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
 	user := domain.User{}
 	err = testDB.Create(&user).Error
 	if err != nil {
 		t.Fatalf("Failed to create user by error %v", err)
 	}
 
+	debtor := domain.User{}
+	err = testDB.Create(&debtor).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+	err = testDB.Create(&domain.LedgerEntry{
+		UserID: debtor.ID,
+		Kind:   domain.LedgerEntryKindFine,
+		Amount: testMaxOutstandingBalance + 1,
+	}).Error
+	if err != nil {
+		t.Fatalf("Failed to create ledgerEntry by error %v", err)
+	}
+
+	now := time.Now()
 	type args struct {
 		p *domain.LendBook
 	}
@@ -41,14 +66,49 @@ func TestPGService_Create(t *testing.T) {
 		args    args
 		wantErr bool
 	}{
+		{
+			name: "failed create by outstanding balance",
+			args: args{
+				&domain.LendBook{
+					BookID: book.ID,
+					UserID: debtor.ID,
+					From:   now,
+					To:     now.AddDate(0, 0, 14),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Success",
 			args: args{
 				&domain.LendBook{
 					BookID: book.ID,
 					UserID: user.ID,
-					From:   time.Now(),
-					To:     time.Now(),
+					From:   now,
+					To:     now.AddDate(0, 0, 14),
+				},
+			},
+		},
+		{
+			name: "failed create by lended book",
+			args: args{
+				&domain.LendBook{
+					BookID: book.ID,
+					UserID: user.ID,
+					From:   now,
+					To:     now.AddDate(0, 0, 14),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success create by booking after lend book",
+			args: args{
+				&domain.LendBook{
+					BookID: book.ID,
+					UserID: user.ID,
+					From:   now.AddDate(0, 1, 0),
+					To:     now.AddDate(0, 1, 14),
 				},
 			},
 		},
@@ -56,7 +116,8 @@ func TestPGService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &pgService{
-				db: testDB,
+				db:                    testDB,
+				maxOutstandingBalance: testMaxOutstandingBalance,
 			}
 			if err := s.Create(context.Background(), tt.args.p); (err != nil) != tt.wantErr {
 				t.Errorf("pgService.Create() error = %v, wantErr %v", err, tt.wantErr)
@@ -65,6 +126,139 @@ func TestPGService_Create(t *testing.T) {
 	}
 }
 
+func TestPGService_CreateByLendingPolicy(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	category := domain.Category{Name: "Reference"}
+	err = testDB.Create(&category).Error
+	if err != nil {
+		t.Fatalf("Failed to create category by error %v", err)
+	}
+
+	book := domain.Book{CategoryID: category.ID}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+	for _, barcode := range []string{"8934974123456", "8934974654321"} {
+		if err := testDB.Create(&domain.BookCopy{BookID: book.ID, Barcode: barcode}).Error; err != nil {
+			t.Fatalf("Failed to create bookCopy by error %v", err)
+		}
+	}
+
+	user := domain.User{Tier: domain.MembershipTierPremium}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	// the policy of both category and tier wins over the others
+	policies := []domain.LendingPolicy{
+		{Name: "premium", Tier: domain.MembershipTierPremium, LoanPeriodDays: 30, MaxActiveLoans: 10},
+		{Name: "reference", CategoryID: category.ID, LoanPeriodDays: 7, MaxActiveLoans: 10},
+		{Name: "premium reference", CategoryID: category.ID, Tier: domain.MembershipTierPremium, LoanPeriodDays: 3, MaxActiveLoans: 1},
+	}
+	for i := range policies {
+		if err := testDB.Create(&policies[i]).Error; err != nil {
+			t.Fatalf("Failed to create lendingPolicy by error %v", err)
+		}
+	}
+
+	s := &pgService{
+		db: testDB,
+	}
+	now := time.Now()
+	lendBook := &domain.LendBook{BookID: book.ID, UserID: user.ID, From: now}
+	if err := s.Create(context.Background(), lendBook); err != nil {
+		t.Fatalf("pgService.Create() error = %v", err)
+	}
+	if !lendBook.To.Equal(now.AddDate(0, 0, 3)) {
+		t.Errorf("pgService.Create() to = %v, want %v", lendBook.To, now.AddDate(0, 0, 3))
+	}
+	if lendBook.LendingPolicyID != policies[2].ID {
+		t.Errorf("pgService.Create() lendingPolicyID = %v, want %v", lendBook.LendingPolicyID, policies[2].ID)
+	}
+
+	err = s.Create(context.Background(), &domain.LendBook{BookID: book.ID, UserID: user.ID, From: now})
+	if err != ErrMaxActiveLoans {
+		t.Errorf("pgService.Create() error = %v, wantErr %v", err, ErrMaxActiveLoans)
+	}
+}
+
+func TestPGService_CreateBatch(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	books := make([]domain.Book, 2)
+	for i := range books {
+		if err := testDB.Create(&books[i]).Error; err != nil {
+			t.Fatalf("Failed to create book by error %v", err)
+		}
+	}
+	// the second book has no copy, so it can not be lended
+	err = testDB.Create(&domain.BookCopy{BookID: books[0].ID, Barcode: "8934974123456"}).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	s := &pgService{
+		db:                    testDB,
+		maxOutstandingBalance: testMaxOutstandingBalance,
+	}
+	now := time.Now()
+	// lend books after a rejected one are still checked
+	err = s.CreateBatch(context.Background(), []*domain.LendBook{
+		{BookID: books[0].ID, UserID: user.ID, From: now},
+		{BookID: books[1].ID, UserID: user.ID, From: now},
+		{BookID: books[0].ID, UserID: user.ID, From: now},
+		{BookID: books[1].ID, UserID: user.ID, From: now},
+	})
+	batchErr, ok := err.(BatchError)
+	if !ok {
+		t.Fatalf("pgService.CreateBatch() error = %v, want BatchError", err)
+	}
+	gotIndexes := []int{}
+	for _, item := range batchErr.Items {
+		gotIndexes = append(gotIndexes, item.Index)
+	}
+	if !reflect.DeepEqual(gotIndexes, []int{1, 2, 3}) {
+		t.Fatalf("pgService.CreateBatch() failed indexes = %v, want %v", gotIndexes, []int{1, 2, 3})
+	}
+
+	var count int
+	if err := testDB.Model(&domain.LendBook{}).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count lendBook by error %v", err)
+	}
+	if count != 0 {
+		t.Errorf("pgService.CreateBatch() created %v lendBooks of failed batch, want 0", count)
+	}
+
+	lendBooks := []*domain.LendBook{{BookID: books[0].ID, UserID: user.ID, From: now}}
+	if err := s.CreateBatch(context.Background(), lendBooks); err != nil {
+		t.Errorf("pgService.CreateBatch() error = %v", err)
+	}
+	if lendBooks[0].ID.IsZero() {
+		t.Errorf("pgService.CreateBatch() lendBook is not created")
+	}
+}
+
 func TestPGService_Update(t *testing.T) {
 	t.Parallel()
 	testDB, _, cleanup := testutil.CreateTestDatabase(t)
@@ -80,6 +274,12 @@ func TestPGService_Update(t *testing.T) {
 		t.Fatalf("Failed to create book by error %v", err)
 	}
 
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
 	user := domain.User{}
 	err = testDB.Create(&user).Error
 	if err != nil {
@@ -109,7 +309,7 @@ func TestPGService_Update(t *testing.T) {
 					BookID: book.ID,
 					UserID: user.ID,
 					From:   time.Now(),
-					To:     time.Now(),
+					To:     time.Now().Add(time.Hour),
 				},
 			},
 		},
@@ -121,7 +321,7 @@ func TestPGService_Update(t *testing.T) {
 					BookID: book.ID,
 					UserID: user.ID,
 					From:   time.Now(),
-					To:     time.Now(),
+					To:     time.Now().Add(time.Hour),
 				},
 			},
 			wantErr: ErrNotFound,
@@ -220,6 +420,7 @@ func TestPGService_FindAll(t *testing.T) {
 	}
 	type args struct {
 		in0 context.Context
+		q   *domain.ListQuery
 	}
 	tests := []struct {
 		name    string
@@ -235,7 +436,7 @@ func TestPGService_FindAll(t *testing.T) {
 			s := &pgService{
 				db: tt.fields.db,
 			}
-			got, err := s.FindAll(tt.args.in0)
+			got, _, err := s.FindAll(tt.args.in0, tt.args.q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("pgService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -326,3 +527,249 @@ func TestPGService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestPGService_Return(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	// lend book is returned late, fine is charged for 3 started days
+	lendBook := domain.LendBook{
+		BookID:     book.ID,
+		BookCopyID: bookCopy.ID,
+		UserID:     user.ID,
+		From:       time.Now().AddDate(0, 0, -10),
+		To:         time.Now().AddDate(0, 0, -3).Add(time.Hour),
+		Status:     domain.LendBookStatusActive,
+	}
+	err = testDB.Create(&lendBook).Error
+	if err != nil {
+		t.Fatalf("Failed to create lendBook by error %v", err)
+	}
+
+	// another user waits for the book
+	holder := domain.User{}
+	err = testDB.Create(&holder).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+	hold := domain.Hold{BookID: book.ID, UserID: holder.ID, Status: domain.HoldStatusWaiting}
+	err = testDB.Create(&hold).Error
+	if err != nil {
+		t.Fatalf("Failed to create hold by error %v", err)
+	}
+
+	fakeLendBookID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p *domain.LendBook
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success return",
+			args: args{
+				&domain.LendBook{
+					Model: domain.Model{ID: lendBook.ID},
+				},
+			},
+		},
+		{
+			name: "failed return by returned book",
+			args: args{
+				&domain.LendBook{
+					Model: domain.Model{ID: lendBook.ID},
+				},
+			},
+			wantErr: ErrReturnedBook,
+		},
+		{
+			name: "failed return by not exist lendBook id",
+			args: args{
+				&domain.LendBook{
+					Model: domain.Model{ID: fakeLendBookID},
+				},
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			got, err := s.Return(context.Background(), tt.args.p)
+			if err != nil && err != tt.wantErr {
+				t.Errorf("pgService.Return() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.wantErr != nil {
+				t.Errorf("pgService.Return() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && got.Status != domain.LendBookStatusReturned {
+				t.Errorf("pgService.Return() status = %v, want %v", got.Status, domain.LendBookStatusReturned)
+			}
+		})
+	}
+
+	// returned copy is kept for the waiting hold
+	gotHold := domain.Hold{Model: domain.Model{ID: hold.ID}}
+	err = testDB.Find(&gotHold).Error
+	if err != nil {
+		t.Fatalf("Failed to find hold by error %v", err)
+	}
+	if gotHold.Status != domain.HoldStatusReady || gotHold.BookCopyID != bookCopy.ID {
+		t.Errorf("pgService.Return() hold = %+v, want ready with copy %v", gotHold, bookCopy.ID)
+	}
+
+	// fine of late return is charged at once
+	var fined int64
+	err = testDB.Model(&domain.LedgerEntry{}).
+		Where("lend_book_id = ? AND kind = ?", lendBook.ID, domain.LedgerEntryKindFine).
+		Select("COALESCE(SUM(amount), 0)").Row().Scan(&fined)
+	if err != nil {
+		t.Fatalf("Failed to sum fines by error %v", err)
+	}
+	if want := 3 * domain.DefaultLendingPolicy().FinePerDay; fined != want {
+		t.Errorf("pgService.Return() fine = %v, want %v", fined, want)
+	}
+
+	// book is available again to the holder after return
+	s := &pgService{db: testDB, maxOutstandingBalance: testMaxOutstandingBalance}
+	err = s.Create(context.Background(), &domain.LendBook{BookID: book.ID, UserID: holder.ID, From: time.Now(), To: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Errorf("pgService.Create() after return error = %v", err)
+	}
+}
+
+func TestPGService_Renew(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	bookCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974123456"}
+	err = testDB.Create(&bookCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	user := domain.User{}
+	err = testDB.Create(&user).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
+	otherCopy := domain.BookCopy{BookID: book.ID, Barcode: "8934974654321"}
+	err = testDB.Create(&otherCopy).Error
+	if err != nil {
+		t.Fatalf("Failed to create bookCopy by error %v", err)
+	}
+
+	newLendBook := func(bookCopyID domain.UUID, renewCount int) domain.LendBook {
+		lendBook := domain.LendBook{
+			BookID:     book.ID,
+			BookCopyID: bookCopyID,
+			UserID:     user.ID,
+			From:       time.Now(),
+			To:         time.Now().AddDate(0, 0, 14),
+			Status:     domain.LendBookStatusActive,
+			RenewCount: renewCount,
+		}
+		if err := testDB.Create(&lendBook).Error; err != nil {
+			t.Fatalf("Failed to create lendBook by error %v", err)
+		}
+		return lendBook
+	}
+	policy := domain.DefaultLendingPolicy()
+	renewable := newLendBook(bookCopy.ID, 0)
+	exhausted := newLendBook(otherCopy.ID, policy.MaxRenewals)
+
+	type args struct {
+		p *domain.LendBook
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success renew",
+			args: args{&domain.LendBook{Model: domain.Model{ID: renewable.ID}}},
+		},
+		{
+			name:    "failed renew by maximum renewals",
+			args:    args{&domain.LendBook{Model: domain.Model{ID: exhausted.ID}}},
+			wantErr: ErrRenewLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			got, err := s.Renew(context.Background(), tt.args.p)
+			if err != nil && err != tt.wantErr {
+				t.Errorf("pgService.Renew() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.wantErr != nil {
+				t.Errorf("pgService.Renew() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && (got.RenewCount != 1 || !got.To.Equal(renewable.To.Add(policy.LoanPeriod()))) {
+				t.Errorf("pgService.Renew() = %+v, want renewed once by %v", got, policy.LoanPeriod())
+			}
+		})
+	}
+
+	// a hold of another user on the book blocks renewal
+	other := domain.User{}
+	err = testDB.Create(&other).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+	err = testDB.Create(&domain.Hold{BookID: book.ID, UserID: other.ID, Status: domain.HoldStatusWaiting}).Error
+	if err != nil {
+		t.Fatalf("Failed to create hold by error %v", err)
+	}
+	s := &pgService{db: testDB}
+	if _, err := s.Renew(context.Background(), &domain.LendBook{Model: domain.Model{ID: renewable.ID}}); err != ErrHeldBook {
+		t.Errorf("pgService.Renew() error = %v, wantErr %v", err, ErrHeldBook)
+	}
+}
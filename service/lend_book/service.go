@@ -4,13 +4,27 @@ import (
 	"context"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
 )
 
+// sorting is fields which list of lend books can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"from":       `"from"`,
+		"to":         `"to"`,
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
 // Service interface for project service
 type Service interface {
 	Create(ctx context.Context, p *domain.LendBook) error
+	CreateBatch(ctx context.Context, p []*domain.LendBook) error
 	Update(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
 	Find(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
-	FindAll(ctx context.Context) ([]domain.LendBook, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error)
 	Delete(ctx context.Context, p *domain.LendBook) error
+	Return(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
+	Renew(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
 }
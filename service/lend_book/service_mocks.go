@@ -11,11 +11,14 @@ import (
 )
 
 var (
-	lockServiceMockCreate  sync.RWMutex
-	lockServiceMockDelete  sync.RWMutex
-	lockServiceMockFind    sync.RWMutex
-	lockServiceMockFindAll sync.RWMutex
-	lockServiceMockUpdate  sync.RWMutex
+	lockServiceMockCreate      sync.RWMutex
+	lockServiceMockCreateBatch sync.RWMutex
+	lockServiceMockDelete      sync.RWMutex
+	lockServiceMockFind        sync.RWMutex
+	lockServiceMockFindAll     sync.RWMutex
+	lockServiceMockRenew       sync.RWMutex
+	lockServiceMockReturn      sync.RWMutex
+	lockServiceMockUpdate      sync.RWMutex
 )
 
 // ServiceMock is a mock implementation of Service.
@@ -27,15 +30,24 @@ var (
 //             CreateFunc: func(ctx context.Context, p *domain.LendBook) error {
 // 	               panic("TODO: mock out the Create method")
 //             },
+//             CreateBatchFunc: func(ctx context.Context, p []*domain.LendBook) error {
+// 	               panic("TODO: mock out the CreateBatch method")
+//             },
 //             DeleteFunc: func(ctx context.Context, p *domain.LendBook) error {
 // 	               panic("TODO: mock out the Delete method")
 //             },
 //             FindFunc: func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
 // 	               panic("TODO: mock out the Find method")
 //             },
-//             FindAllFunc: func(ctx context.Context) ([]domain.LendBook, error) {
+//             FindAllFunc: func(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error) {
 // 	               panic("TODO: mock out the FindAll method")
 //             },
+//             RenewFunc: func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+// 	               panic("TODO: mock out the Renew method")
+//             },
+//             ReturnFunc: func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+// 	               panic("TODO: mock out the Return method")
+//             },
 //             UpdateFunc: func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
 // 	               panic("TODO: mock out the Update method")
 //             },
@@ -49,6 +61,9 @@ type ServiceMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, p *domain.LendBook) error
 
+	// CreateBatchFunc mocks the CreateBatch method.
+	CreateBatchFunc func(ctx context.Context, p []*domain.LendBook) error
+
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, p *domain.LendBook) error
 
@@ -56,7 +71,13 @@ type ServiceMock struct {
 	FindFunc func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
 
 	// FindAllFunc mocks the FindAll method.
-	FindAllFunc func(ctx context.Context) ([]domain.LendBook, error)
+	FindAllFunc func(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error)
+
+	// RenewFunc mocks the Renew method.
+	RenewFunc func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
+
+	// ReturnFunc mocks the Return method.
+	ReturnFunc func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
 
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error)
@@ -70,6 +91,13 @@ type ServiceMock struct {
 			// P is the p argument value.
 			P *domain.LendBook
 		}
+		// CreateBatch holds details about calls to the CreateBatch method.
+		CreateBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P []*domain.LendBook
+		}
 		// Delete holds details about calls to the Delete method.
 		Delete []struct {
 			// Ctx is the ctx argument value.
@@ -88,6 +116,22 @@ type ServiceMock struct {
 		FindAll []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Q is the q argument value.
+			Q *domain.ListQuery
+		}
+		// Renew holds details about calls to the Renew method.
+		Renew []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *domain.LendBook
+		}
+		// Return holds details about calls to the Return method.
+		Return []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *domain.LendBook
 		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
@@ -134,6 +178,41 @@ func (mock *ServiceMock) CreateCalls() []struct {
 	return calls
 }
 
+// CreateBatch calls CreateBatchFunc.
+func (mock *ServiceMock) CreateBatch(ctx context.Context, p []*domain.LendBook) error {
+	if mock.CreateBatchFunc == nil {
+		panic("ServiceMock.CreateBatchFunc: method is nil but Service.CreateBatch was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   []*domain.LendBook
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	lockServiceMockCreateBatch.Lock()
+	mock.calls.CreateBatch = append(mock.calls.CreateBatch, callInfo)
+	lockServiceMockCreateBatch.Unlock()
+	return mock.CreateBatchFunc(ctx, p)
+}
+
+// CreateBatchCalls gets all the calls that were made to CreateBatch.
+// Check the length with:
+//     len(mockedService.CreateBatchCalls())
+func (mock *ServiceMock) CreateBatchCalls() []struct {
+	Ctx context.Context
+	P   []*domain.LendBook
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   []*domain.LendBook
+	}
+	lockServiceMockCreateBatch.RLock()
+	calls = mock.calls.CreateBatch
+	lockServiceMockCreateBatch.RUnlock()
+	return calls
+}
+
 // Delete calls DeleteFunc.
 func (mock *ServiceMock) Delete(ctx context.Context, p *domain.LendBook) error {
 	if mock.DeleteFunc == nil {
@@ -205,19 +284,21 @@ func (mock *ServiceMock) FindCalls() []struct {
 }
 
 // FindAll calls FindAllFunc.
-func (mock *ServiceMock) FindAll(ctx context.Context) ([]domain.LendBook, error) {
+func (mock *ServiceMock) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendBook, *domain.ListMeta, error) {
 	if mock.FindAllFunc == nil {
 		panic("ServiceMock.FindAllFunc: method is nil but Service.FindAll was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
+		Q   *domain.ListQuery
 	}{
 		Ctx: ctx,
+		Q:   q,
 	}
 	lockServiceMockFindAll.Lock()
 	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
 	lockServiceMockFindAll.Unlock()
-	return mock.FindAllFunc(ctx)
+	return mock.FindAllFunc(ctx, q)
 }
 
 // FindAllCalls gets all the calls that were made to FindAll.
@@ -225,9 +306,11 @@ func (mock *ServiceMock) FindAll(ctx context.Context) ([]domain.LendBook, error)
 //     len(mockedService.FindAllCalls())
 func (mock *ServiceMock) FindAllCalls() []struct {
 	Ctx context.Context
+	Q   *domain.ListQuery
 } {
 	var calls []struct {
 		Ctx context.Context
+		Q   *domain.ListQuery
 	}
 	lockServiceMockFindAll.RLock()
 	calls = mock.calls.FindAll
@@ -235,6 +318,76 @@ func (mock *ServiceMock) FindAllCalls() []struct {
 	return calls
 }
 
+// Renew calls RenewFunc.
+func (mock *ServiceMock) Renew(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+	if mock.RenewFunc == nil {
+		panic("ServiceMock.RenewFunc: method is nil but Service.Renew was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *domain.LendBook
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	lockServiceMockRenew.Lock()
+	mock.calls.Renew = append(mock.calls.Renew, callInfo)
+	lockServiceMockRenew.Unlock()
+	return mock.RenewFunc(ctx, p)
+}
+
+// RenewCalls gets all the calls that were made to Renew.
+// Check the length with:
+//     len(mockedService.RenewCalls())
+func (mock *ServiceMock) RenewCalls() []struct {
+	Ctx context.Context
+	P   *domain.LendBook
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *domain.LendBook
+	}
+	lockServiceMockRenew.RLock()
+	calls = mock.calls.Renew
+	lockServiceMockRenew.RUnlock()
+	return calls
+}
+
+// Return calls ReturnFunc.
+func (mock *ServiceMock) Return(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
+	if mock.ReturnFunc == nil {
+		panic("ServiceMock.ReturnFunc: method is nil but Service.Return was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *domain.LendBook
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	lockServiceMockReturn.Lock()
+	mock.calls.Return = append(mock.calls.Return, callInfo)
+	lockServiceMockReturn.Unlock()
+	return mock.ReturnFunc(ctx, p)
+}
+
+// ReturnCalls gets all the calls that were made to Return.
+// Check the length with:
+//     len(mockedService.ReturnCalls())
+func (mock *ServiceMock) ReturnCalls() []struct {
+	Ctx context.Context
+	P   *domain.LendBook
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *domain.LendBook
+	}
+	lockServiceMockReturn.RLock()
+	calls = mock.calls.Return
+	lockServiceMockReturn.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *ServiceMock) Update(ctx context.Context, p *domain.LendBook) (*domain.LendBook, error) {
 	if mock.UpdateFunc == nil {
@@ -0,0 +1,22 @@
+package lending_policy
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrNotFound                = apperror.New("lending_policy.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown                 = apperror.New("lending_policy.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound          = apperror.New("lending_policy.record_not_found", http.StatusNotFound, "client record not found")
+	ErrNameIsRequired          = apperror.New("lending_policy.name_is_required", http.StatusBadRequest, "Name of policy is required")
+	ErrTierIsInvalid           = apperror.New("lending_policy.tier_is_invalid", http.StatusBadRequest, "Tier is invalid")
+	ErrLoanPeriodDaysIsInvalid = apperror.New("lending_policy.loan_period_days_is_invalid", http.StatusBadRequest, "Loan period days must be greater than 0")
+	ErrMaxActiveLoansIsInvalid = apperror.New("lending_policy.max_active_loans_is_invalid", http.StatusBadRequest, "Max active loans must be greater than 0")
+	ErrMaxRenewalsIsInvalid    = apperror.New("lending_policy.max_renewals_is_invalid", http.StatusBadRequest, "Max renewals must not be negative")
+	ErrFinePerDayIsInvalid     = apperror.New("lending_policy.fine_per_day_is_invalid", http.StatusBadRequest, "Fine per day must not be negative")
+	ErrCategoryIDNotExist      = apperror.New("lending_policy.category_id_not_exist", http.StatusNotFound, "ID of category not exist in table categories")
+	ErrExistPolicy             = apperror.New("lending_policy.exist_policy", http.StatusBadRequest, "A policy of the category and tier is exist")
+)
@@ -0,0 +1,52 @@
+package lending_policy
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits any authenticated caller to read lending policies, only admins to change them
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, policy *domain.LendingPolicy) error {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, policy)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendingPolicy, *domain.ListMeta, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw authorizationMiddleware) Find(ctx context.Context, policy *domain.LendingPolicy) (*domain.LendingPolicy, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.Find(ctx, policy)
+}
+func (mw authorizationMiddleware) Update(ctx context.Context, policy *domain.LendingPolicy) (*domain.LendingPolicy, error) {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return mw.Service.Update(ctx, policy)
+}
+func (mw authorizationMiddleware) Delete(ctx context.Context, policy *domain.LendingPolicy) error {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	return mw.Service.Delete(ctx, policy)
+}
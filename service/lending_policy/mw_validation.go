@@ -0,0 +1,80 @@
+package lending_policy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
+)
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+// validate check rules of policy, an empty tier matches all tiers
+func validate(policy *domain.LendingPolicy) error {
+	errs := validation.Errors{}
+	policy.Name = strings.TrimSpace(policy.Name)
+	if policy.Name == "" {
+		errs.Add("name", validation.CodeRequired, ErrNameIsRequired)
+	}
+	if policy.Tier != "" && !policy.Tier.IsValid() {
+		errs.Add("tier", validation.CodeInvalid, ErrTierIsInvalid)
+	}
+	if policy.LoanPeriodDays <= 0 {
+		errs.Add("loan_period_days", validation.CodeOutOfRange, ErrLoanPeriodDaysIsInvalid)
+	}
+	if policy.MaxActiveLoans <= 0 {
+		errs.Add("max_active_loans", validation.CodeOutOfRange, ErrMaxActiveLoansIsInvalid)
+	}
+	if policy.MaxRenewals < 0 {
+		errs.Add("max_renewals", validation.CodeOutOfRange, ErrMaxRenewalsIsInvalid)
+	}
+	if policy.FinePerDay < 0 {
+		errs.Add("fine_per_day", validation.CodeOutOfRange, ErrFinePerDayIsInvalid)
+	}
+	return errs.Err()
+}
+
+func (mw validationMiddleware) Create(ctx context.Context, policy *domain.LendingPolicy) (err error) {
+	if err := validate(policy); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, policy)
+}
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendingPolicy, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "category_id", "tier"); err != nil {
+		return nil, nil, err
+	}
+	if _, err := list.FilterUUID(q, "category_id"); err != nil {
+		return nil, nil, err
+	}
+	if tier := domain.MembershipTier(q.Filter("tier")); tier != "" && !tier.IsValid() {
+		return nil, nil, ErrTierIsInvalid
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw validationMiddleware) Find(ctx context.Context, policy *domain.LendingPolicy) (*domain.LendingPolicy, error) {
+	return mw.Service.Find(ctx, policy)
+}
+
+func (mw validationMiddleware) Update(ctx context.Context, policy *domain.LendingPolicy) (*domain.LendingPolicy, error) {
+	if err := validate(policy); err != nil {
+		return nil, err
+	}
+	return mw.Service.Update(ctx, policy)
+}
+func (mw validationMiddleware) Delete(ctx context.Context, policy *domain.LendingPolicy) error {
+	return mw.Service.Delete(ctx, policy)
+}
@@ -0,0 +1,126 @@
+package lending_policy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_Create(t *testing.T) {
+	serviceMock := &ServiceMock{
+		CreateFunc: func(_ context.Context, p *domain.LendingPolicy) error {
+			return nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	type args struct {
+		p *domain.LendingPolicy
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name: "valid lendingPolicy",
+			args: args{&domain.LendingPolicy{
+				Name:           "Premium",
+				Tier:           domain.MembershipTierPremium,
+				LoanPeriodDays: 30,
+				MaxActiveLoans: 10,
+				MaxRenewals:    3,
+				FinePerDay:     50,
+			}},
+		},
+		{
+			name: "valid lendingPolicy by category without tier",
+			args: args{&domain.LendingPolicy{
+				Name:           "Reference",
+				CategoryID:     domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4"),
+				LoanPeriodDays: 7,
+				MaxActiveLoans: 1,
+			}},
+		},
+		{
+			name: "invalid lendingPolicy by missing name",
+			args: args{&domain.LendingPolicy{
+				LoanPeriodDays: 30,
+				MaxActiveLoans: 10,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendingPolicy by unknown tier",
+			args: args{&domain.LendingPolicy{
+				Name:           "Gold",
+				Tier:           "gold",
+				LoanPeriodDays: 30,
+				MaxActiveLoans: 10,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendingPolicy by missing loan period",
+			args: args{&domain.LendingPolicy{
+				Name:           "Premium",
+				MaxActiveLoans: 10,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendingPolicy by missing max active loans",
+			args: args{&domain.LendingPolicy{
+				Name:           "Premium",
+				LoanPeriodDays: 30,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid lendingPolicy by negative fine",
+			args: args{&domain.LendingPolicy{
+				Name:           "Premium",
+				LoanPeriodDays: 30,
+				MaxActiveLoans: 10,
+				FinePerDay:     -1,
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			err := mw.Create(defaultCtx, tt.args.p)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.Create() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.Create() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package lending_policy
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// pgService implmenter for LendingPolicy serivce in postgres
+type pgService struct {
+	db *gorm.DB
+}
+
+// NewPGService create new PGService
+func NewPGService(db *gorm.DB) Service {
+	return &pgService{
+		db: db,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// checkScope check category of policy p exists and no other policy
+// than the one of exceptID has the same category and tier
+func (s *pgService) checkScope(p *domain.LendingPolicy, exceptID domain.UUID) error {
+	if !p.CategoryID.IsZero() {
+		var errExistCaID = s.db.Where("id = ?", p.CategoryID).Find(&domain.Category{}).Error
+		if errExistCaID != nil {
+			if errExistCaID == gorm.ErrRecordNotFound {
+				return ErrCategoryIDNotExist
+			}
+			return errExistCaID
+		}
+	}
+
+	var count int
+	q := s.db.Model(&domain.LendingPolicy{}).
+		Where("category_id IS NOT DISTINCT FROM ? AND tier = ?", p.CategoryID, p.Tier)
+	if !exceptID.IsZero() {
+		q = q.Where("id <> ?", exceptID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrExistPolicy
+	}
+	return nil
+}
+
+// isExistPolicyError check err is raised by unique index of category and
+// tier, a concurrent policy of the same scope may be stored after checkScope
+func isExistPolicyError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "lending_policies_category_id_tier_idx"
+}
+
+// Create implement Create for LendingPolicy service
+func (s *pgService) Create(ctx context.Context, p *domain.LendingPolicy) error {
+	s = s.withContext(ctx)
+	if err := s.checkScope(p, domain.UUID{}); err != nil {
+		return err
+	}
+	if err := s.db.Create(p).Error; err != nil {
+		if isExistPolicyError(err) {
+			return ErrExistPolicy
+		}
+		return err
+	}
+	return nil
+}
+
+// Update implement Update for LendingPolicy service, policy is versioned: the
+// new values are stored as a new policy and the old one is deleted, so lend
+// books keep the policy which was resolved for them and fines are not changed
+// afterwards. The new policy with its own ID is returned
+func (s *pgService) Update(ctx context.Context, p *domain.LendingPolicy) (*domain.LendingPolicy, error) {
+	s = s.withContext(ctx)
+	res := domain.LendingPolicy{
+		Name:           p.Name,
+		CategoryID:     p.CategoryID,
+		Tier:           p.Tier,
+		LoanPeriodDays: p.LoanPeriodDays,
+		MaxActiveLoans: p.MaxActiveLoans,
+		MaxRenewals:    p.MaxRenewals,
+		FinePerDay:     p.FinePerDay,
+	}
+	err := pg.Transaction(s.db, func(tx *gorm.DB) error {
+		// policy is locked so it is replaced by one version only
+		old := domain.LendingPolicy{Model: domain.Model{ID: p.ID}}
+		if err := tx.Set("gorm:query_option", "FOR UPDATE").Find(&old).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrNotFound
+			}
+			return err
+		}
+		txService := &pgService{db: tx}
+		if err := txService.checkScope(p, old.ID); err != nil {
+			return err
+		}
+
+		// old policy is deleted first, it holds the scope in unique index otherwise
+		if err := tx.Delete(&old).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&res).Error; err != nil {
+			if isExistPolicyError(err) {
+				return ErrExistPolicy
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Find implement Find for LendingPolicy service
+func (s *pgService) Find(ctx context.Context, p *domain.LendingPolicy) (*domain.LendingPolicy, error) {
+	s = s.withContext(ctx)
+	res := p
+	if err := s.db.Find(res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// FindAll implement FindAll for LendingPolicy service
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendingPolicy, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	categoryID, err := list.FilterUUID(q, "category_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !categoryID.IsZero() {
+		db = db.Where("category_id = ?", categoryID)
+	}
+	if tier := q.Filter("tier"); tier != "" {
+		db = db.Where("tier = ?", tier)
+	}
+
+	res := []domain.LendingPolicy{}
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, meta, nil
+}
+
+// Delete implement Delete for LendingPolicy service, lend books keep
+// the deleted policy which was resolved for them
+func (s *pgService) Delete(ctx context.Context, p *domain.LendingPolicy) error {
+	s = s.withContext(ctx)
+	old := domain.LendingPolicy{Model: domain.Model{ID: p.ID}}
+	if err := s.db.Find(&old).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrNotFound
+		}
+		return err
+	}
+	return s.db.Delete(old).Error
+}
@@ -0,0 +1,167 @@
+package lending_policy
+
+import (
+	"context"
+	"testing"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestPGService_Create(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	category := domain.Category{Name: "Reference"}
+	err = testDB.Create(&category).Error
+	if err != nil {
+		t.Fatalf("Failed to create category by error %v", err)
+	}
+
+	fakeCategoryID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p *domain.LendingPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success create by category",
+			args: args{&domain.LendingPolicy{
+				Name:           "Reference",
+				CategoryID:     category.ID,
+				LoanPeriodDays: 7,
+				MaxActiveLoans: 1,
+			}},
+		},
+		{
+			name: "success create by category and tier",
+			args: args{&domain.LendingPolicy{
+				Name:           "Premium reference",
+				CategoryID:     category.ID,
+				Tier:           domain.MembershipTierPremium,
+				LoanPeriodDays: 14,
+				MaxActiveLoans: 2,
+			}},
+		},
+		{
+			name: "failed create by exist policy",
+			args: args{&domain.LendingPolicy{
+				Name:           "Reference again",
+				CategoryID:     category.ID,
+				LoanPeriodDays: 7,
+				MaxActiveLoans: 1,
+			}},
+			wantErr: ErrExistPolicy,
+		},
+		{
+			name: "failed create by not exist category",
+			args: args{&domain.LendingPolicy{
+				Name:           "Unknown",
+				CategoryID:     fakeCategoryID,
+				LoanPeriodDays: 7,
+				MaxActiveLoans: 1,
+			}},
+			wantErr: ErrCategoryIDNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			if err := s.Create(context.Background(), tt.args.p); err != tt.wantErr {
+				t.Errorf("pgService.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGService_Update(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	standard := domain.LendingPolicy{Name: "Standard", Tier: domain.MembershipTierStandard, LoanPeriodDays: 14, MaxActiveLoans: 5}
+	premium := domain.LendingPolicy{Name: "Premium", Tier: domain.MembershipTierPremium, LoanPeriodDays: 30, MaxActiveLoans: 10}
+	for _, p := range []*domain.LendingPolicy{&standard, &premium} {
+		if err := testDB.Create(p).Error; err != nil {
+			t.Fatalf("Failed to create lendingPolicy by error %v", err)
+		}
+	}
+
+	fakePolicyID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p *domain.LendingPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success update",
+			args: args{&domain.LendingPolicy{
+				Model:          domain.Model{ID: premium.ID},
+				Name:           "Premium",
+				Tier:           domain.MembershipTierPremium,
+				LoanPeriodDays: 21,
+				MaxActiveLoans: 8,
+			}},
+		},
+		{
+			name: "failed update by exist policy",
+			args: args{&domain.LendingPolicy{
+				Model:          domain.Model{ID: standard.ID},
+				Name:           "Standard",
+				Tier:           domain.MembershipTierPremium,
+				LoanPeriodDays: 21,
+				MaxActiveLoans: 8,
+			}},
+			wantErr: ErrExistPolicy,
+		},
+		{
+			name: "failed update by not found",
+			args: args{&domain.LendingPolicy{
+				Model:          domain.Model{ID: fakePolicyID},
+				Name:           "Unknown",
+				LoanPeriodDays: 21,
+				MaxActiveLoans: 8,
+			}},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			if _, err := s.Update(context.Background(), tt.args.p); err != tt.wantErr {
+				t.Errorf("pgService.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// updated policy is kept for lend books which were lended by it
+	old := domain.LendingPolicy{}
+	err = testDB.Unscoped().Where("id = ?", premium.ID).First(&old).Error
+	if err != nil {
+		t.Fatalf("Failed to find lendingPolicy by error %v", err)
+	}
+	if old.DeletedAt == nil || old.LoanPeriodDays != premium.LoanPeriodDays {
+		t.Errorf("pgService.Update() old policy = %+v, want deleted with loan period %v", old, premium.LoanPeriodDays)
+	}
+}
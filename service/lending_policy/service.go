@@ -0,0 +1,26 @@
+package lending_policy
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// sorting is fields which list of lending policies can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
+// Service interface for project service
+type Service interface {
+	Create(ctx context.Context, p *domain.LendingPolicy) error
+	Update(ctx context.Context, p *domain.LendingPolicy) (*domain.LendingPolicy, error)
+	Find(ctx context.Context, p *domain.LendingPolicy) (*domain.LendingPolicy, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.LendingPolicy, *domain.ListMeta, error)
+	Delete(ctx context.Context, p *domain.LendingPolicy) error
+}
@@ -0,0 +1,15 @@
+package list
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrInvalidLimit     = apperror.New("list.invalid_limit", http.StatusBadRequest, "Limit must be between 1 and 100")
+	ErrInvalidSortField = apperror.New("list.invalid_sort_field", http.StatusBadRequest, "List can not be sorted by the field")
+	ErrInvalidFilter    = apperror.New("list.invalid_filter", http.StatusBadRequest, "List can not be filtered by the filter")
+	ErrInvalidCursor    = apperror.New("list.invalid_cursor", http.StatusBadRequest, "Cursor is invalid")
+)
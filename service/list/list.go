@@ -0,0 +1,184 @@
+// Package list implement filtering, sorting and cursor pagination
+// which are shared by FindAll of services
+package list
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+// Limits of a page
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Sorting describe fields which a list can be sorted by, Columns maps a field
+// to its column and Default is used when query has no sort
+type Sorting struct {
+	Columns map[string]string
+	Default []domain.SortField
+}
+
+// Validate check limit, sort fields and filters of query q,
+// filters which are not in filters are refused
+func Validate(q *domain.ListQuery, sorting Sorting, filters ...string) error {
+	if q.Limit < 0 || q.Limit > MaxLimit {
+		return ErrInvalidLimit
+	}
+	for _, sort := range q.Sort {
+		if _, ok := sorting.Columns[sort.Field]; !ok {
+			return ErrInvalidSortField
+		}
+	}
+	for name := range q.Filters {
+		known := false
+		for _, filter := range filters {
+			if name == filter {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return ErrInvalidFilter
+		}
+	}
+	return nil
+}
+
+// Contains return pattern of LIKE which matches values containing s
+func Contains(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
+
+// cursor is position after a row in a list sorted by Sort
+type cursor struct {
+	Sort   string        `json:"s"`
+	Values []interface{} `json:"v"`
+}
+
+// sortKey return sort as text, a cursor is valid only for the same sort
+func sortKey(sort []domain.SortField) string {
+	keys := make([]string, 0, len(sort))
+	for _, s := range sort {
+		if s.Desc {
+			keys = append(keys, "-"+s.Field)
+		} else {
+			keys = append(keys, s.Field)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// Find load a page of rows of db into out which is pointer to slice, db may have
+// conditions of filters already. Rows are sorted by query q then by id, so a page
+// starts right after the row of cursor even though rows are added meanwhile.
+func Find(db *gorm.DB, q *domain.ListQuery, sorting Sorting, out interface{}) (*domain.ListMeta, error) {
+	meta := &domain.ListMeta{}
+	if err := db.Model(out).Count(&meta.Total).Error; err != nil {
+		return nil, err
+	}
+
+	sort := q.Sort
+	if len(sort) == 0 {
+		sort = sorting.Default
+	}
+	sort = append(append([]domain.SortField{}, sort...), domain.SortField{Field: "id"})
+	columns := make([]string, len(sort))
+	for i, s := range sort {
+		columns[i] = sorting.Columns[s.Field]
+		if s.Field == "id" {
+			columns[i] = "id"
+		}
+	}
+
+	if q.Cursor != "" {
+		data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		c := cursor{}
+		if err := json.Unmarshal(data, &c); err != nil || c.Sort != sortKey(sort) || len(c.Values) != len(sort) {
+			return nil, ErrInvalidCursor
+		}
+
+		// rows after cursor: (a > va) OR (a = va AND b > vb) OR ...
+		conds := make([]string, 0, len(sort))
+		args := []interface{}{}
+		for i, s := range sort {
+			parts := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				parts = append(parts, columns[j]+" = ?")
+				args = append(args, c.Values[j])
+			}
+			op := " > ?"
+			if s.Desc {
+				op = " < ?"
+			}
+			parts = append(parts, columns[i]+op)
+			args = append(args, c.Values[i])
+			conds = append(conds, "("+strings.Join(parts, " AND ")+")")
+		}
+		db = db.Where(strings.Join(conds, " OR "), args...)
+	}
+
+	for i, s := range sort {
+		if s.Desc {
+			db = db.Order(columns[i] + " DESC")
+		} else {
+			db = db.Order(columns[i])
+		}
+	}
+
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	// one more row tells there is a next page
+	if err := db.Limit(limit + 1).Find(out).Error; err != nil {
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(out).Elem()
+	if rows.Len() <= limit {
+		return meta, nil
+	}
+	rows.Set(rows.Slice(0, limit))
+
+	last := db.NewScope(rows.Index(limit - 1).Addr().Interface())
+	c := cursor{Sort: sortKey(sort), Values: make([]interface{}, len(sort))}
+	for i, column := range columns {
+		field, ok := last.FieldByName(strings.Trim(column, `"`))
+		if !ok {
+			return nil, ErrInvalidSortField
+		}
+		c.Values[i] = field.Field.Interface()
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	meta.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+	return meta, nil
+}
+
+// FilterUUID return filter name of query q as UUID, it is zero
+// when the filter is not given
+func FilterUUID(q *domain.ListQuery, name string) (domain.UUID, error) {
+	value := q.Filter(name)
+	if value == "" {
+		return domain.UUID{}, nil
+	}
+	id, err := domain.UUIDFromString(value)
+	if err != nil {
+		return domain.UUID{}, ErrInvalidFilter
+	}
+	return id, nil
+}
@@ -0,0 +1,56 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestValidate(t *testing.T) {
+	sorting := Sorting{
+		Columns: map[string]string{"created_at": "created_at", "name": "name"},
+		Default: []domain.SortField{{Field: "created_at"}},
+	}
+	tests := []struct {
+		name    string
+		args    domain.ListQuery
+		wantErr error
+	}{
+		{
+			name: "valid query",
+			args: domain.ListQuery{
+				Filters: map[string][]string{"name": {"go"}},
+				Sort:    []domain.SortField{{Field: "name", Desc: true}},
+				Limit:   MaxLimit,
+			},
+		},
+		{
+			name:    "invalid query by limit",
+			args:    domain.ListQuery{Limit: MaxLimit + 1},
+			wantErr: ErrInvalidLimit,
+		},
+		{
+			name:    "invalid query by sort field",
+			args:    domain.ListQuery{Sort: []domain.SortField{{Field: "email"}}},
+			wantErr: ErrInvalidSortField,
+		},
+		{
+			name:    "invalid query by filter",
+			args:    domain.ListQuery{Filters: map[string][]string{"email": {"a@b.c"}}},
+			wantErr: ErrInvalidFilter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(&tt.args, sorting, "name"); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	if got, want := Contains(`50%_off\`), `%50\%\_off\\%`; got != want {
+		t.Errorf("Contains() = %v, want %v", got, want)
+	}
+}
@@ -1,16 +1,31 @@
 package service
 
 import (
+	"github.com/phungvandat/example-go/service/api_key"
+	"github.com/phungvandat/example-go/service/auth"
 	"github.com/phungvandat/example-go/service/book"
+	"github.com/phungvandat/example-go/service/book_copy"
 	"github.com/phungvandat/example-go/service/category"
+	"github.com/phungvandat/example-go/service/fine"
+	"github.com/phungvandat/example-go/service/hold"
 	"github.com/phungvandat/example-go/service/lend_book"
+	"github.com/phungvandat/example-go/service/lending_policy"
+	"github.com/phungvandat/example-go/service/tag"
 	"github.com/phungvandat/example-go/service/user"
 )
 
 // Service define list of all services in projects
 type Service struct {
+	AuthService     auth.Service
+	APIKeyService   api_key.Service
 	UserService     user.Service
 	CategoryService category.Service
 	BookService     book.Service
+	BookCopyService book_copy.Service
 	LendBookService lend_book.Service
+	HoldService     hold.Service
+	FineService     fine.Service
+
+	LendingPolicyService lending_policy.Service
+	TagService           tag.Service
 }
@@ -0,0 +1,19 @@
+package tag
+
+import (
+	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
+)
+
+// Error Declaration
+var (
+	ErrNotFound          = apperror.New("tag.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown           = apperror.New("tag.unknown", http.StatusBadRequest, "unknown error")
+	ErrRecordNotFound    = apperror.New("tag.record_not_found", http.StatusNotFound, "client record not found")
+	ErrBookIDIsRequired  = apperror.New("tag.book_id_is_required", http.StatusBadRequest, "ID of book is required")
+	ErrBookIDNotExist    = apperror.New("tag.book_id_not_exist", http.StatusNotFound, "ID of book not exist in table books")
+	ErrTagIsRequired     = apperror.New("tag.tag_is_required", http.StatusBadRequest, "At least one tag is required")
+	ErrNameIsRequired    = apperror.New("tag.name_is_required", http.StatusBadRequest, "Name of tag is required")
+	ErrMaximumLengthName = apperror.New("tag.maximum_length_name", http.StatusBadRequest, "Name of tag is length <= 50 characters")
+)
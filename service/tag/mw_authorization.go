@@ -0,0 +1,41 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits any authenticated caller to read tags, only
+// staff to tag books
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+func (mw authorizationMiddleware) AddToBook(ctx context.Context, book *domain.Book, names []string) ([]domain.Tag, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.AddToBook(ctx, book, names)
+}
+func (mw authorizationMiddleware) RemoveFromBook(ctx context.Context, book *domain.Book, names []string) ([]domain.Tag, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, err
+	}
+	return mw.Service.RemoveFromBook(ctx, book, names)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Tag, *domain.ListMeta, error) {
+	if _, err := auth.Require(ctx); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
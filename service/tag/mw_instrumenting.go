@@ -0,0 +1,57 @@
+package tag
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type instrumentingMiddleware struct {
+	Service
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Histogram
+}
+
+// InstrumentingMiddleware record count, errors and latency of every call of
+// service, labeled by service and method
+func InstrumentingMiddleware(requests, errors metrics.Counter, latency metrics.Histogram) func(Service) Service {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:  next,
+			requests: requests.With("service", "tag"),
+			errors:   errors.With("service", "tag"),
+			latency:  latency.With("service", "tag"),
+		}
+	}
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	mw.requests.With("method", method).Add(1)
+	if err != nil {
+		mw.errors.With("method", method).Add(1)
+	}
+	mw.latency.With("method", method).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) AddToBook(ctx context.Context, book *domain.Book, names []string) (res []domain.Tag, err error) {
+	defer func(begin time.Time) {
+		mw.observe("AddToBook", begin, err)
+	}(time.Now())
+	return mw.Service.AddToBook(ctx, book, names)
+}
+func (mw instrumentingMiddleware) RemoveFromBook(ctx context.Context, book *domain.Book, names []string) (res []domain.Tag, err error) {
+	defer func(begin time.Time) {
+		mw.observe("RemoveFromBook", begin, err)
+	}(time.Now())
+	return mw.Service.RemoveFromBook(ctx, book, names)
+}
+func (mw instrumentingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.Tag, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAll", begin, err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
@@ -0,0 +1,68 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// maxLengthName is the greatest length of name of tag
+const maxLengthName = 50
+
+type validationMiddleware struct {
+	Service
+}
+
+// ValidationMiddleware ...
+func ValidationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &validationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+// validateNames normalize names of tags and check them
+func validateNames(names []string) ([]string, error) {
+	names = domain.NormalizeTagNames(names)
+	if len(names) == 0 {
+		return nil, ErrTagIsRequired
+	}
+	for _, name := range names {
+		if name == "" {
+			return nil, ErrNameIsRequired
+		}
+		if len(name) > maxLengthName {
+			return nil, ErrMaximumLengthName
+		}
+	}
+	return names, nil
+}
+
+func (mw validationMiddleware) AddToBook(ctx context.Context, book *domain.Book, names []string) ([]domain.Tag, error) {
+	if book.ID.IsZero() {
+		return nil, ErrBookIDIsRequired
+	}
+	names, err := validateNames(names)
+	if err != nil {
+		return nil, err
+	}
+	return mw.Service.AddToBook(ctx, book, names)
+}
+func (mw validationMiddleware) RemoveFromBook(ctx context.Context, book *domain.Book, names []string) ([]domain.Tag, error) {
+	if book.ID.IsZero() {
+		return nil, ErrBookIDIsRequired
+	}
+	names, err := validateNames(names)
+	if err != nil {
+		return nil, err
+	}
+	return mw.Service.RemoveFromBook(ctx, book, names)
+}
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Tag, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "name"); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
@@ -0,0 +1,96 @@
+package tag
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_validationMiddleware_AddToBook(t *testing.T) {
+	var gotNames []string
+	serviceMock := &ServiceMock{
+		AddToBookFunc: func(_ context.Context, p *domain.Book, names []string) ([]domain.Tag, error) {
+			gotNames = names
+			return []domain.Tag{}, nil
+		},
+	}
+
+	defaultCtx := context.Background()
+	bookID := domain.MustGetUUIDFromString("dc9076e9-2fda-4019-bd2c-900a8284b9c4")
+	type args struct {
+		p     *domain.Book
+		names []string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantNames       []string
+		wantErr         bool
+		errorStatusCode int
+	}{
+		{
+			name:      "valid tags are normalized",
+			args:      args{&domain.Book{Model: domain.Model{ID: bookID}}, []string{" Science", "science", "FICTION "}},
+			wantNames: []string{"science", "fiction"},
+		},
+		{
+			name:            "invalid tags by missing bookID",
+			args:            args{&domain.Book{}, []string{"science"}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "invalid tags by no tag",
+			args:            args{&domain.Book{Model: domain.Model{ID: bookID}}, []string{}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "invalid tags by empty name",
+			args:            args{&domain.Book{Model: domain.Model{ID: bookID}}, []string{"science", "  "}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "invalid tags by too long name",
+			args:            args{&domain.Book{Model: domain.Model{ID: bookID}}, []string{"a very long tag name which is longer than fifty characters"}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := validationMiddleware{
+				Service: serviceMock,
+			}
+			_, err := mw.AddToBook(defaultCtx, tt.args.p, tt.args.names)
+			if err != nil {
+				if tt.wantErr == false {
+					t.Errorf("validationMiddleware.AddToBook() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				status, ok := err.(interface{ StatusCode() int })
+				if !ok {
+					t.Errorf("validationMiddleware.AddToBook() error %v doesn't implement StatusCode()", err)
+				}
+				if tt.errorStatusCode != status.StatusCode() {
+					t.Errorf("validationMiddleware.AddToBook() status = %v, want status code %v", status.StatusCode(), tt.errorStatusCode)
+					return
+				}
+
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("validationMiddleware.AddToBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("validationMiddleware.AddToBook() names = %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
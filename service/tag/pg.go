@@ -0,0 +1,159 @@
+package tag
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/phungvandat/example-go/config/database/pg"
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
+)
+
+// pgService implmenter for Tag serivce in postgres
+type pgService struct {
+	db *gorm.DB
+}
+
+// NewPGService create new PGService
+func NewPGService(db *gorm.DB) Service {
+	return &pgService{
+		db: db,
+	}
+}
+
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// findOrCreate find tag by name, the tag is created when it does not exist
+func (s *pgService) findOrCreate(name string) (*domain.Tag, error) {
+	tag := domain.Tag{}
+	err := s.db.Where("name = ?", name).First(&tag).Error
+	if err == nil {
+		return &tag, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	tag.Name = name
+	if err := s.db.Create(&tag).Error; err != nil {
+		// the tag may be created by another request in the meantime
+		if errFind := s.db.Where("name = ?", name).First(&tag).Error; errFind == nil {
+			return &tag, nil
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// findByBook find tags of book, ordered by name
+func (s *pgService) findByBook(bookID domain.UUID) ([]domain.Tag, error) {
+	res := []domain.Tag{}
+	return res, s.db.
+		Joins("JOIN book_tags bt ON bt.tag_id = tags.id").
+		Where("bt.book_id = ?", bookID).
+		Order("tags.name").
+		Find(&res).Error
+}
+
+// checkBook check book p exists
+func (s *pgService) checkBook(p *domain.Book) error {
+	var errExistBoID = s.db.Where("id = ?", p.ID).Find(&domain.Book{}).Error
+	if errExistBoID != nil {
+		if errExistBoID == gorm.ErrRecordNotFound {
+			return ErrBookIDNotExist
+		}
+		return errExistBoID
+	}
+	return nil
+}
+
+// AddToBook implement AddToBook for Tag service, a tag which
+// the book already has is skipped
+func (s *pgService) AddToBook(ctx context.Context, p *domain.Book, names []string) ([]domain.Tag, error) {
+	s = s.withContext(ctx)
+	if err := s.checkBook(p); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		tag, err := s.findOrCreate(name)
+		if err != nil {
+			return nil, err
+		}
+		err = s.db.Exec(`INSERT INTO book_tags (book_id, tag_id, created_at)
+			SELECT ?, ?, now()
+			WHERE NOT EXISTS (SELECT 1 FROM book_tags WHERE book_id = ? AND tag_id = ?)`,
+			p.ID, tag.ID, p.ID, tag.ID).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.findByBook(p.ID)
+}
+
+// RemoveFromBook implement RemoveFromBook for Tag service
+func (s *pgService) RemoveFromBook(ctx context.Context, p *domain.Book, names []string) ([]domain.Tag, error) {
+	s = s.withContext(ctx)
+	if err := s.checkBook(p); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Exec(`DELETE FROM book_tags
+		WHERE book_id = ? AND tag_id IN (SELECT id FROM tags WHERE name IN (?))`,
+		p.ID, names).Error
+	if err != nil {
+		return nil, err
+	}
+	return s.findByBook(p.ID)
+}
+
+// FindAll implement FindAll for Tag service, a tag is counted
+// for each book which is not deleted
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Tag, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	if name := q.Filter("name"); name != "" {
+		db = db.Where("name LIKE ?", list.Contains(strings.ToLower(name)))
+	}
+
+	res := []domain.Tag{}
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(res) == 0 {
+		return res, meta, nil
+	}
+
+	counts := []struct {
+		TagID     domain.UUID
+		BookCount int
+	}{}
+	ids := make([]domain.UUID, len(res))
+	for i := range res {
+		ids[i] = res[i].ID
+	}
+	err = s.db.Raw(`SELECT bt.tag_id, COUNT(*) AS book_count FROM book_tags bt
+		JOIN books b ON b.id = bt.book_id AND b.deleted_at IS NULL
+		WHERE bt.tag_id IN (?)
+		GROUP BY bt.tag_id`, ids).Scan(&counts).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	byTag := make(map[domain.UUID]int, len(counts))
+	for _, c := range counts {
+		byTag[c.TagID] = c.BookCount
+	}
+	for i := range res {
+		res[i].BookCount = byTag[res[i].ID]
+	}
+	return res, meta, nil
+}
@@ -0,0 +1,113 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	testutil "github.com/phungvandat/example-go/config/database/pg/util"
+	"github.com/phungvandat/example-go/domain"
+)
+
+func TestPGService_AddToBook(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	book := domain.Book{}
+	err = testDB.Create(&book).Error
+	if err != nil {
+		t.Fatalf("Failed to create book by error %v", err)
+	}
+
+	fakeBookID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+
+	type args struct {
+		p     *domain.Book
+		names []string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantTags int
+		wantErr  error
+	}{
+		{
+			name:     "success add tags",
+			args:     args{&domain.Book{Model: domain.Model{ID: book.ID}}, []string{"science", "fiction"}},
+			wantTags: 2,
+		},
+		{
+			name:     "success add tag which the book has",
+			args:     args{&domain.Book{Model: domain.Model{ID: book.ID}}, []string{"science", "history"}},
+			wantTags: 3,
+		},
+		{
+			name:    "failed add tags by not exist book",
+			args:    args{&domain.Book{Model: domain.Model{ID: fakeBookID}}, []string{"science"}},
+			wantErr: ErrBookIDNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &pgService{
+				db: testDB,
+			}
+			got, err := s.AddToBook(context.Background(), tt.args.p, tt.args.names)
+			if err != tt.wantErr {
+				t.Errorf("pgService.AddToBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && len(got) != tt.wantTags {
+				t.Errorf("pgService.AddToBook() tags = %v, want %v tags", got, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestPGService_FindAll(t *testing.T) {
+	t.Parallel()
+	testDB, _, cleanup := testutil.CreateTestDatabase(t)
+	defer cleanup()
+	err := testutil.MigrateTables(testDB)
+	if err != nil {
+		t.Fatalf("Failed to migrate table by error %v", err)
+	}
+
+	books := make([]domain.Book, 2)
+	for i := range books {
+		if err := testDB.Create(&books[i]).Error; err != nil {
+			t.Fatalf("Failed to create book by error %v", err)
+		}
+	}
+
+	s := &pgService{
+		db: testDB,
+	}
+	for _, book := range books {
+		if _, err := s.AddToBook(context.Background(), &book, []string{"science"}); err != nil {
+			t.Fatalf("Failed to add tags by error %v", err)
+		}
+	}
+	if _, err := s.AddToBook(context.Background(), &books[0], []string{"fiction"}); err != nil {
+		t.Fatalf("Failed to add tags by error %v", err)
+	}
+	if _, err := s.RemoveFromBook(context.Background(), &books[0], []string{"fiction"}); err != nil {
+		t.Fatalf("Failed to remove tags by error %v", err)
+	}
+
+	got, _, err := s.FindAll(context.Background(), &domain.ListQuery{})
+	if err != nil {
+		t.Fatalf("pgService.FindAll() error = %v", err)
+	}
+	counts := map[string]int{}
+	for _, tag := range got {
+		counts[tag.Name] = tag.BookCount
+	}
+	if counts["science"] != 2 || counts["fiction"] != 0 {
+		t.Errorf("pgService.FindAll() counts = %v, want science 2 and fiction 0", counts)
+	}
+}
@@ -0,0 +1,24 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+)
+
+// sorting is fields which list of tags can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	},
+	Default: []domain.SortField{{Field: "name"}},
+}
+
+// Service interface for project service
+type Service interface {
+	AddToBook(ctx context.Context, p *domain.Book, names []string) ([]domain.Tag, error)
+	RemoveFromBook(ctx context.Context, p *domain.Book, names []string) ([]domain.Tag, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.Tag, *domain.ListMeta, error)
+}
@@ -2,69 +2,21 @@ package user
 
 import (
 	"net/http"
+
+	"github.com/phungvandat/example-go/service/apperror"
 )
 
 // Error Declaration
 var (
-	ErrNotFound        = errNotFound{}
-	ErrUnknown         = errUnknown{}
-	ErrNameIsRequired  = errNameIsRequired{}
-	ErrEmailIsRequired = errEmailIsRequired{}
-	ErrEmailIsInvalid  = errEmailIsInvalid{}
-	ErrRecordNotFound  = errRecordNotFound{}
+	ErrNotFound              = apperror.New("user.not_found", http.StatusNotFound, "record not found")
+	ErrUnknown               = apperror.New("user.unknown", http.StatusBadRequest, "unknown error")
+	ErrNameIsRequired        = apperror.New("user.name_is_required", http.StatusBadRequest, "user name is required")
+	ErrEmailIsRequired       = apperror.New("user.email_is_required", http.StatusBadRequest, "email is required")
+	ErrEmailIsInvalid        = apperror.New("user.email_is_invalid", http.StatusBadRequest, "email address is invalid")
+	ErrExistEmail            = apperror.New("user.exist_email", http.StatusBadRequest, "email is used by another user")
+	ErrRecordNotFound        = apperror.New("user.record_not_found", http.StatusNotFound, "client record not found")
+	ErrTierIsInvalid         = apperror.New("user.tier_is_invalid", http.StatusBadRequest, "membership tier is invalid")
+	ErrRoleIsInvalid         = apperror.New("user.role_is_invalid", http.StatusBadRequest, "role is invalid")
+	ErrPasswordIsRequired    = apperror.New("user.password_is_required", http.StatusBadRequest, "password is required")
+	ErrMinimumLengthPassword = apperror.New("user.minimum_length_password", http.StatusBadRequest, "password must have at least 8 characters")
 )
-
-type errNotFound struct{}
-
-func (errNotFound) Error() string {
-	return "record not found"
-}
-func (errNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errUnknown struct{}
-
-func (errUnknown) Error() string {
-	return "unknown error"
-}
-func (errUnknown) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errEmailIsRequired struct{}
-
-func (errEmailIsRequired) Error() string {
-	return "email is required"
-}
-func (errEmailIsRequired) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errEmailIsInvalid struct{}
-
-func (errEmailIsInvalid) Error() string {
-	return "email address is invalid"
-}
-func (errEmailIsInvalid) StatusCode() int {
-	return http.StatusBadRequest
-}
-
-type errRecordNotFound struct{}
-
-func (errRecordNotFound) Error() string {
-	return "client record not found"
-}
-func (errRecordNotFound) StatusCode() int {
-	return http.StatusNotFound
-}
-
-type errNameIsRequired struct{}
-
-func (errNameIsRequired) Error() string {
-	return "user name is required"
-}
-
-func (errNameIsRequired) StatusCode() int {
-	return http.StatusBadRequest
-}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"context"
+
+	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+)
+
+type authorizationMiddleware struct {
+	Service
+}
+
+// AuthorizationMiddleware permits members to sign up and to manage their own
+// account, staff to manage members and only admins to grant roles
+func AuthorizationMiddleware() func(Service) Service {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+		}
+	}
+}
+
+// authorizePrivileges check principal p may set role and tier of user, a
+// nil p is a caller who signs up
+func authorizePrivileges(p *auth.Principal, user *domain.User) error {
+	isAdmin := p != nil && p.Role == domain.RoleAdmin
+	isStaff := p != nil && p.Role.IsStaff()
+	if user.Role != "" && user.Role != domain.RoleMember && !isAdmin {
+		return auth.ErrForbidden
+	}
+	if user.Tier != "" && user.Tier != domain.MembershipTierStandard && !isStaff {
+		return auth.ErrForbidden
+	}
+	return nil
+}
+
+func (mw authorizationMiddleware) Create(ctx context.Context, user *domain.User) error {
+	// a caller without token signs up as a member, members can not create
+	// accounts of others
+	p, ok := auth.FromContext(ctx)
+	if ok && !p.Role.IsStaff() {
+		return auth.ErrForbidden
+	}
+	if err := authorizePrivileges(p, user); err != nil {
+		return err
+	}
+	return mw.Service.Create(ctx, user)
+}
+func (mw authorizationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error) {
+	if _, err := auth.RequireStaff(ctx); err != nil {
+		return nil, nil, err
+	}
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw authorizationMiddleware) Find(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if _, err := auth.RequireSelfOrStaff(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return mw.Service.Find(ctx, user)
+}
+func (mw authorizationMiddleware) Update(ctx context.Context, user *domain.User) (*domain.User, error) {
+	p, err := auth.RequireSelfOrStaff(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizePrivileges(p, user); err != nil {
+		return nil, err
+	}
+	// librarians manage members only, or they could take over staff accounts
+	if p.Role == domain.RoleLibrarian && p.UserID != user.ID {
+		target, err := mw.Service.Find(ctx, &domain.User{Model: domain.Model{ID: user.ID}})
+		if err != nil {
+			return nil, err
+		}
+		if target.Role != domain.RoleMember {
+			return nil, auth.ErrForbidden
+		}
+	}
+	return mw.Service.Update(ctx, user)
+}
+func (mw authorizationMiddleware) Delete(ctx context.Context, user *domain.User) error {
+	if _, err := auth.Require(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	return mw.Service.Delete(ctx, user)
+}
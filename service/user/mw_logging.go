@@ -0,0 +1,56 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+type loggingMiddleware struct {
+	Service
+	logger log.Logger
+}
+
+// LoggingMiddleware log every call of service with its duration and error
+func LoggingMiddleware(logger log.Logger) func(Service) Service {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			Service: next,
+			logger:  log.With(logger, "service", "user"),
+		}
+	}
+}
+
+func (mw loggingMiddleware) Create(ctx context.Context, user *domain.User) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "user_id", user.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Create(ctx, user)
+}
+func (mw loggingMiddleware) Update(ctx context.Context, user *domain.User) (res *domain.User, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Update", "user_id", user.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Update(ctx, user)
+}
+func (mw loggingMiddleware) Find(ctx context.Context, user *domain.User) (res *domain.User, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "user_id", user.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Find(ctx, user)
+}
+func (mw loggingMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) (res []domain.User, meta *domain.ListMeta, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "FindAll", "count", len(res), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, q)
+}
+func (mw loggingMiddleware) Delete(ctx context.Context, user *domain.User) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "user_id", user.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, user)
+}
@@ -0,0 +1,35 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/phungvandat/example-go/domain"
+)
+
+func Test_loggingMiddleware_Find(t *testing.T) {
+	serviceMock := &ServiceMock{
+		FindFunc: func(_ context.Context, p *domain.User) (*domain.User, error) {
+			return nil, ErrNotFound
+		},
+	}
+
+	var buf bytes.Buffer
+	mw := LoggingMiddleware(log.NewLogfmtLogger(&buf))(serviceMock)
+	userID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
+	_, err := mw.Find(context.Background(), &domain.User{Model: domain.Model{ID: userID}})
+	if err != ErrNotFound {
+		t.Fatalf("loggingMiddleware.Find() error = %v, wantErr %v", err, ErrNotFound)
+	}
+
+	line := buf.String()
+	for _, want := range []string{"service=user", "method=Find", "user_id=" + userID.String(), "took=", `err="record not found"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("loggingMiddleware.Find() logged %q, want it contains %q", line, want)
+		}
+	}
+}
@@ -5,8 +5,13 @@ import (
 	"regexp"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/service/validation"
 )
 
+// minLengthPassword is the least length of password
+const minLengthPassword = 8
+
 // Declare Regex
 const (
 	emailRegex = "^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$"
@@ -25,41 +30,81 @@ func ValidationMiddleware() func(Service) Service {
 	}
 }
 
-func (mw validationMiddleware) Create(ctx context.Context, user *domain.User) (err error) {
+// validateContact check name and email of user
+func validateContact(user *domain.User, errs *validation.Errors) {
 	if user.Name == "" {
-		return ErrNameIsRequired
+		errs.Add("name", validation.CodeRequired, ErrNameIsRequired)
 	}
 
+	emailRegexp, _ := regexp.Compile(emailRegex)
 	if user.Email == "" {
-		return ErrEmailIsRequired
+		errs.Add("email", validation.CodeRequired, ErrEmailIsRequired)
+	} else if !emailRegexp.MatchString(user.Email) {
+		errs.Add("email", validation.CodeInvalid, ErrEmailIsInvalid)
 	}
+}
 
-	emailRegexp, _ := regexp.Compile(emailRegex)
-	if !emailRegexp.MatchString(user.Email) {
-		return ErrEmailIsInvalid
+func (mw validationMiddleware) Create(ctx context.Context, user *domain.User) (err error) {
+	errs := validation.Errors{}
+	validateContact(user, &errs)
+
+	if user.Tier == "" {
+		user.Tier = domain.MembershipTierStandard
+	}
+	if !user.Tier.IsValid() {
+		errs.Add("tier", validation.CodeInvalid, ErrTierIsInvalid)
+	}
+	if user.Role == "" {
+		user.Role = domain.RoleMember
+	}
+	if !user.Role.IsValid() {
+		errs.Add("role", validation.CodeInvalid, ErrRoleIsInvalid)
+	}
+	if user.Password == "" {
+		errs.Add("password", validation.CodeRequired, ErrPasswordIsRequired)
+	} else if len(user.Password) < minLengthPassword {
+		errs.Add("password", validation.CodeTooShort, ErrMinimumLengthPassword)
+	}
+	if err := errs.Err(); err != nil {
+		return err
 	}
 
 	return mw.Service.Create(ctx, user)
 }
-func (mw validationMiddleware) FindAll(ctx context.Context) ([]domain.User, error) {
-	return mw.Service.FindAll(ctx)
+func (mw validationMiddleware) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error) {
+	if err := list.Validate(q, sorting, "name", "email", "tier", "role"); err != nil {
+		return nil, nil, err
+	}
+	if tier := domain.MembershipTier(q.Filter("tier")); tier != "" && !tier.IsValid() {
+		return nil, nil, ErrTierIsInvalid
+	}
+	if role := domain.Role(q.Filter("role")); role != "" && !role.IsValid() {
+		return nil, nil, ErrRoleIsInvalid
+	}
+	return mw.Service.FindAll(ctx, q)
 }
 func (mw validationMiddleware) Find(ctx context.Context, user *domain.User) (*domain.User, error) {
 	return mw.Service.Find(ctx, user)
 }
 
 func (mw validationMiddleware) Update(ctx context.Context, user *domain.User) (*domain.User, error) {
-	if user.Name == "" {
-		return nil, ErrNameIsRequired
-	}
+	errs := validation.Errors{}
+	validateContact(user, &errs)
 
-	if user.Email == "" {
-		return nil, ErrEmailIsRequired
+	// tier is kept when it is omitted
+	if user.Tier != "" && !user.Tier.IsValid() {
+		errs.Add("tier", validation.CodeInvalid, ErrTierIsInvalid)
 	}
-
-	emailRegexp, _ := regexp.Compile(emailRegex)
-	if !emailRegexp.MatchString(user.Email) {
-		return nil, ErrEmailIsInvalid
+	// role is kept when it is omitted
+	if user.Role != "" && !user.Role.IsValid() {
+		errs.Add("role", validation.CodeInvalid, ErrRoleIsInvalid)
+	}
+	// password is kept when it is omitted
+	if user.Password != "" && len(user.Password) < minLengthPassword {
+		errs.Add("password", validation.CodeTooShort, ErrMinimumLengthPassword)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
 	}
 
 	return mw.Service.Update(ctx, user)
@@ -63,6 +63,16 @@ func Test_validationMiddleware_Update(t *testing.T) {
 			wantErr:         true,
 			errorStatusCode: http.StatusBadRequest,
 		},
+		{
+			name: "invalid user by unknown tier",
+			args: args{&domain.User{
+				Name:  "Curabitur vulputate vestibulum lorem.",
+				Email: "example@gmail.com",
+				Tier:  "gold",
+			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
+		},
 		{
 			name:            "invalid user by missing attribute",
 			args:            args{&domain.User{}},
@@ -121,9 +131,20 @@ func Test_validationMiddleware_Create(t *testing.T) {
 		{
 			name: "valid user",
 			args: args{&domain.User{
-				Name:  "Curabitur vulputate vestibulum lorem.",
-				Email: "example@gmail.com",
+				Name:     "Curabitur vulputate vestibulum lorem.",
+				Email:    "example@gmail.com",
+				Password: "s3cret-password",
+			}},
+		},
+		{
+			name: "invalid user by too short password",
+			args: args{&domain.User{
+				Name:     "Curabitur vulputate vestibulum lorem.",
+				Email:    "example@gmail.com",
+				Password: "short",
 			}},
+			wantErr:         true,
+			errorStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:            "invalid user by missing name",
@@ -222,6 +243,7 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 	}
 	type args struct {
 		ctx context.Context
+		q   *domain.ListQuery
 	}
 	tests := []struct {
 		name       string
@@ -237,7 +259,7 @@ func Test_validationMiddleware_FindAll(t *testing.T) {
 			mw := validationMiddleware{
 				Service: tt.fields.Service,
 			}
-			gotOutput, err := mw.FindAll(tt.args.ctx)
+			gotOutput, _, err := mw.FindAll(tt.args.ctx, tt.args.q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validationMiddleware.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
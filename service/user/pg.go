@@ -4,8 +4,13 @@ import (
 	"context"
 
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
 
+	"github.com/phungvandat/example-go/config/database/pg"
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/auth"
+	"github.com/phungvandat/example-go/service/list"
+	"github.com/phungvandat/example-go/trace"
 )
 
 // pgService implmenter for User serivce in postgres
@@ -20,13 +25,42 @@ func NewPGService(db *gorm.DB) Service {
 	}
 }
 
+// withContext return copy of s whose statements run in ctx
+func (s *pgService) withContext(ctx context.Context) *pgService {
+	c := *s
+	c.db = trace.WithDB(ctx, pg.WithContext(ctx, s.db))
+	return &c
+}
+
+// isExistEmailError check err is raised by unique index of email, which
+// is compared regardless of case
+func isExistEmailError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "users_lower_email_idx"
+}
+
 // Create implement Create for User service
-func (s *pgService) Create(_ context.Context, p *domain.User) error {
-	return s.db.Create(p).Error
+func (s *pgService) Create(ctx context.Context, p *domain.User) error {
+	s = s.withContext(ctx)
+	if p.Password != "" {
+		hash, err := auth.HashPassword(p.Password)
+		if err != nil {
+			return err
+		}
+		p.PasswordHash = hash
+	}
+	if err := s.db.Create(p).Error; err != nil {
+		if isExistEmailError(err) {
+			return ErrExistEmail
+		}
+		return err
+	}
+	return nil
 }
 
 // Update implement Update for User service
-func (s *pgService) Update(_ context.Context, p *domain.User) (*domain.User, error) {
+func (s *pgService) Update(ctx context.Context, p *domain.User) (*domain.User, error) {
+	s = s.withContext(ctx)
 	old := domain.User{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -37,12 +71,32 @@ func (s *pgService) Update(_ context.Context, p *domain.User) (*domain.User, err
 
 	old.Name = p.Name
 	old.Email = p.Email
+	if p.Tier != "" {
+		old.Tier = p.Tier
+	}
+	if p.Role != "" {
+		old.Role = p.Role
+	}
+	if p.Password != "" {
+		hash, err := auth.HashPassword(p.Password)
+		if err != nil {
+			return nil, err
+		}
+		old.PasswordHash = hash
+	}
 
-	return &old, s.db.Save(&old).Error
+	if err := s.db.Save(&old).Error; err != nil {
+		if isExistEmailError(err) {
+			return nil, ErrExistEmail
+		}
+		return nil, err
+	}
+	return &old, nil
 }
 
 // Find implement Find for User service
-func (s *pgService) Find(_ context.Context, p *domain.User) (*domain.User, error) {
+func (s *pgService) Find(ctx context.Context, p *domain.User) (*domain.User, error) {
+	s = s.withContext(ctx)
 	res := p
 	if err := s.db.Find(&res).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -55,13 +109,33 @@ func (s *pgService) Find(_ context.Context, p *domain.User) (*domain.User, error
 }
 
 // FindAll implement FindAll for User service
-func (s *pgService) FindAll(_ context.Context) ([]domain.User, error) {
+func (s *pgService) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error) {
+	s = s.withContext(ctx)
+	db := s.db
+	if name := q.Filter("name"); name != "" {
+		db = db.Where("name ILIKE ?", list.Contains(name))
+	}
+	if email := q.Filter("email"); email != "" {
+		db = db.Where("email ILIKE ?", list.Contains(email))
+	}
+	if tier := q.Filter("tier"); tier != "" {
+		db = db.Where("tier = ?", tier)
+	}
+	if role := q.Filter("role"); role != "" {
+		db = db.Where("role = ?", role)
+	}
+
 	res := []domain.User{}
-	return res, s.db.Find(&res).Error
+	meta, err := list.Find(db, q, sorting, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, meta, nil
 }
 
 // Delete implement Delete for User service
-func (s *pgService) Delete(_ context.Context, p *domain.User) error {
+func (s *pgService) Delete(ctx context.Context, p *domain.User) error {
+	s = s.withContext(ctx)
 	old := domain.User{Model: domain.Model{ID: p.ID}}
 	if err := s.db.Find(&old).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
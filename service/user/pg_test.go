@@ -39,6 +39,16 @@ func TestPGService_Create(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Failed by exist email in other case",
+			args: args{
+				&domain.User{
+					Name:  "Create New User 2",
+					Email: "Example@Gmail.com",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -67,6 +77,12 @@ func TestPGService_Update(t *testing.T) {
 		t.Fatalf("Failed to create user by error %v", err)
 	}
 
+	other := domain.User{Email: "other@gmail.com"}
+	err = testDB.Create(&other).Error
+	if err != nil {
+		t.Fatalf("Failed to create user by error %v", err)
+	}
+
 	fakeUserID := domain.MustGetUUIDFromString("1698bbd6-e0c8-4957-a5a9-8c536970994b")
 
 	type args struct {
@@ -77,6 +93,17 @@ func TestPGService_Update(t *testing.T) {
 		args    args
 		wantErr error
 	}{
+		{
+			name: "failed update by exist email",
+			args: args{
+				&domain.User{
+					Model: domain.Model{ID: user.ID},
+					Name:  "user Name 1",
+					Email: "Other@gmail.com",
+				},
+			},
+			wantErr: ErrExistEmail,
+		},
 		{
 			name: "success update",
 			args: args{
@@ -192,6 +219,7 @@ func TestPGService_FindAll(t *testing.T) {
 	}
 	type args struct {
 		in0 context.Context
+		q   *domain.ListQuery
 	}
 	tests := []struct {
 		name    string
@@ -207,7 +235,7 @@ func TestPGService_FindAll(t *testing.T) {
 			s := &pgService{
 				db: tt.fields.db,
 			}
-			got, err := s.FindAll(tt.args.in0)
+			got, _, err := s.FindAll(tt.args.in0, tt.args.q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("pgService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
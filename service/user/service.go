@@ -4,13 +4,24 @@ import (
 	"context"
 
 	"github.com/phungvandat/example-go/domain"
+	"github.com/phungvandat/example-go/service/list"
 )
 
+// sorting is fields which list of users can be sorted by
+var sorting = list.Sorting{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+		"email":      "email",
+	},
+	Default: []domain.SortField{{Field: "created_at"}},
+}
+
 // Service interface for project service
 type Service interface {
 	Create(ctx context.Context, p *domain.User) error
 	Update(ctx context.Context, p *domain.User) (*domain.User, error)
 	Find(ctx context.Context, p *domain.User) (*domain.User, error)
-	FindAll(ctx context.Context) ([]domain.User, error)
+	FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error)
 	Delete(ctx context.Context, p *domain.User) error
 }
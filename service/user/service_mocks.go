@@ -33,7 +33,7 @@ var (
 //             FindFunc: func(ctx context.Context, p *domain.User) (*domain.User, error) {
 // 	               panic("TODO: mock out the Find method")
 //             },
-//             FindAllFunc: func(ctx context.Context) ([]domain.User, error) {
+//             FindAllFunc: func(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error) {
 // 	               panic("TODO: mock out the FindAll method")
 //             },
 //             UpdateFunc: func(ctx context.Context, p *domain.User) (*domain.User, error) {
@@ -56,7 +56,7 @@ type ServiceMock struct {
 	FindFunc func(ctx context.Context, p *domain.User) (*domain.User, error)
 
 	// FindAllFunc mocks the FindAll method.
-	FindAllFunc func(ctx context.Context) ([]domain.User, error)
+	FindAllFunc func(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error)
 
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, p *domain.User) (*domain.User, error)
@@ -88,6 +88,8 @@ type ServiceMock struct {
 		FindAll []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Q is the q argument value.
+			Q *domain.ListQuery
 		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
@@ -205,19 +207,21 @@ func (mock *ServiceMock) FindCalls() []struct {
 }
 
 // FindAll calls FindAllFunc.
-func (mock *ServiceMock) FindAll(ctx context.Context) ([]domain.User, error) {
+func (mock *ServiceMock) FindAll(ctx context.Context, q *domain.ListQuery) ([]domain.User, *domain.ListMeta, error) {
 	if mock.FindAllFunc == nil {
 		panic("ServiceMock.FindAllFunc: method is nil but Service.FindAll was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
+		Q   *domain.ListQuery
 	}{
 		Ctx: ctx,
+		Q:   q,
 	}
 	lockServiceMockFindAll.Lock()
 	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
 	lockServiceMockFindAll.Unlock()
-	return mock.FindAllFunc(ctx)
+	return mock.FindAllFunc(ctx, q)
 }
 
 // FindAllCalls gets all the calls that were made to FindAll.
@@ -225,9 +229,11 @@ func (mock *ServiceMock) FindAll(ctx context.Context) ([]domain.User, error) {
 //     len(mockedService.FindAllCalls())
 func (mock *ServiceMock) FindAllCalls() []struct {
 	Ctx context.Context
+	Q   *domain.ListQuery
 } {
 	var calls []struct {
 		Ctx context.Context
+		Q   *domain.ListQuery
 	}
 	lockServiceMockFindAll.RLock()
 	calls = mock.calls.FindAll
@@ -0,0 +1,74 @@
+// Package validation collect errors of fields which are validated together,
+// so all of them are reported at once instead of the first one
+package validation
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Codes of field errors, they are stable for clients to match on
+const (
+	CodeRequired   = "required"
+	CodeInvalid    = "invalid"
+	CodeTooShort   = "too_short"
+	CodeTooLong    = "too_long"
+	CodeOutOfRange = "out_of_range"
+)
+
+// FieldError describe why value of Field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors is errors of fields, it takes status code of its first error
+type Errors struct {
+	Items  []FieldError
+	status int
+}
+
+// Add add err of field with code
+func (e *Errors) Add(field, code string, err error) {
+	if len(e.Items) == 0 {
+		if sc, ok := err.(interface{ StatusCode() int }); ok {
+			e.status = sc.StatusCode()
+		}
+	}
+	e.Items = append(e.Items, FieldError{Field: field, Code: code, Message: err.Error()})
+}
+
+// Err return errors of fields, it is nil when no error is added
+func (e *Errors) Err() error {
+	if len(e.Items) == 0 {
+		return nil
+	}
+	return *e
+}
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e.Items))
+	for _, item := range e.Items {
+		messages = append(messages, item.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// StatusCode return status code of the first error, bad request by default
+func (e Errors) StatusCode() int {
+	if e.status == 0 {
+		return http.StatusBadRequest
+	}
+	return e.status
+}
+
+// Code return code of error
+func (Errors) Code() string {
+	return "validation_failed"
+}
+
+// Details return errors of fields
+func (e Errors) Details() interface{} {
+	return e.Items
+}
@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type errConflict struct{}
+
+func (errConflict) Error() string {
+	return "Name is existed"
+}
+func (errConflict) StatusCode() int {
+	return http.StatusConflict
+}
+
+func TestErrors(t *testing.T) {
+	errs := Errors{}
+	if err := errs.Err(); err != nil {
+		t.Fatalf("Errors.Err() = %v, want nil", err)
+	}
+
+	errs.Add("name", CodeInvalid, errConflict{})
+	errs.Add("email", CodeRequired, errors.New("Email is required"))
+	err := errs.Err()
+	if err == nil {
+		t.Fatal("Errors.Err() = nil, want errors")
+	}
+	if got, want := err.Error(), "Name is existed; Email is required"; got != want {
+		t.Errorf("Errors.Error() = %v, want %v", got, want)
+	}
+	if got := err.(Errors).StatusCode(); got != http.StatusConflict {
+		t.Errorf("Errors.StatusCode() = %v, want %v", got, http.StatusConflict)
+	}
+
+	want := []FieldError{
+		{Field: "name", Code: CodeInvalid, Message: "Name is existed"},
+		{Field: "email", Code: CodeRequired, Message: "Email is required"},
+	}
+	if got := err.(Errors).Details(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Errors.Details() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,22 @@
+package trace
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// EndpointMiddleware record a span name around endpoint, it is a child of
+// span of transport which starts the request
+func EndpointMiddleware(name string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			ctx, span := StartSpan(ctx, name, KindInternal)
+			defer func() {
+				span.SetError(err)
+				span.Finish()
+			}()
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Exporter send spans which end to where they are stored, Export must not
+// block callers for long as it is called on every span
+type Exporter interface {
+	Export(s *Span)
+}
+
+// zipkinSpan is span in Zipkin v2 JSON, which is accepted by Zipkin, Jaeger
+// and OpenTelemetry collectors
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Kind          string            `json:"kind,omitempty"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint map[string]string `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+func toZipkin(s *Span, serviceName string) zipkinSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := zipkinSpan{
+		TraceID:       s.Context.TraceID.String(),
+		ID:            s.Context.SpanID.String(),
+		Name:          s.Name,
+		Timestamp:     s.Start.UnixNano() / int64(time.Microsecond),
+		Duration:      int64(s.End.Sub(s.Start) / time.Microsecond),
+		LocalEndpoint: map[string]string{"serviceName": serviceName},
+		Tags:          make(map[string]string, len(s.Tags)+1),
+	}
+	// zipkin has no internal kind, such spans have no kind
+	if s.Kind != KindInternal {
+		res.Kind = s.Kind
+	}
+	if !s.ParentID.IsZero() {
+		res.ParentID = s.ParentID.String()
+	}
+	for k, v := range s.Tags {
+		res.Tags[k] = v
+	}
+	if s.Err != "" {
+		res.Tags["error"] = s.Err
+	}
+	return res
+}
+
+// writerExporter write every span as a JSON line
+type writerExporter struct {
+	mu          sync.Mutex
+	enc         *json.Encoder
+	serviceName string
+}
+
+// NewWriterExporter create Exporter which writes spans to w, ie. os.Stdout
+func NewWriterExporter(w io.Writer, serviceName string) Exporter {
+	return &writerExporter{enc: json.NewEncoder(w), serviceName: serviceName}
+}
+
+func (e *writerExporter) Export(s *Span) {
+	span := toZipkin(s, e.serviceName)
+	e.mu.Lock()
+	e.enc.Encode(span)
+	e.mu.Unlock()
+}
+
+// CollectorExporter send spans in batches to a collector by Zipkin v2 API
+type CollectorExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	spans       chan zipkinSpan
+	done        chan struct{}
+	onError     func(error)
+}
+
+// Settings of batches which are sent to collector
+const (
+	collectorQueueSize     = 1000
+	collectorBatchSize     = 100
+	collectorFlushInterval = time.Second
+)
+
+// NewCollectorExporter create exporter which sends spans to url, ie.
+// http://localhost:9411/api/v2/spans, errors of sending are given to
+// onError. Spans are dropped when the queue is full so requests never wait
+func NewCollectorExporter(url, serviceName string, onError func(error)) *CollectorExporter {
+	e := &CollectorExporter{
+		url:         url,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		spans:       make(chan zipkinSpan, collectorQueueSize),
+		done:        make(chan struct{}),
+		onError:     onError,
+	}
+	go e.run()
+	return e
+}
+
+// Export implement Exporter
+func (e *CollectorExporter) Export(s *Span) {
+	select {
+	case e.spans <- toZipkin(s, e.serviceName):
+	default:
+	}
+}
+
+// Close send spans which are queued and stop e
+func (e *CollectorExporter) Close() {
+	close(e.spans)
+	<-e.done
+}
+
+func (e *CollectorExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(collectorFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]zipkinSpan, 0, collectorBatchSize)
+	for {
+		select {
+		case s, ok := <-e.spans:
+			if !ok {
+				e.send(batch)
+				return
+			}
+			batch = append(batch, s)
+			if len(batch) < collectorBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		e.send(batch)
+		batch = batch[:0]
+	}
+}
+
+func (e *CollectorExporter) send(batch []zipkinSpan) {
+	if len(batch) == 0 {
+		return
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		e.onError(err)
+		return
+	}
+	res, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		e.onError(err)
+		return
+	}
+	res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		e.onError(&collectorError{status: res.Status})
+	}
+}
+
+type collectorError struct {
+	status string
+}
+
+func (e *collectorError) Error() string {
+	return "trace: collector responds " + e.status
+}
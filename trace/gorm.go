@@ -0,0 +1,67 @@
+package trace
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+)
+
+// keys of settings of gorm which carry trace
+const (
+	gormContextKey = "trace:context"
+	gormSpanKey    = "trace:span"
+)
+
+// WithDB return db which carries ctx, its statements are spans of span of ctx
+func WithDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.Set(gormContextKey, ctx)
+}
+
+// RegisterCallbacks make every statement which carries a context, see
+// WithDB, record a span with its SQL. Callbacks are shared by every DB gorm
+// opens, so DBs bound to a context of request record spans too
+func RegisterCallbacks() {
+	callbacks := gorm.DefaultCallback
+	callbacks.Create().Before("gorm:create").Register("trace:before_create", before("create"))
+	callbacks.Create().After("gorm:create").Register("trace:after_create", after)
+	callbacks.Query().Before("gorm:query").Register("trace:before_query", before("query"))
+	callbacks.Query().After("gorm:query").Register("trace:after_query", after)
+	callbacks.Update().Before("gorm:update").Register("trace:before_update", before("update"))
+	callbacks.Update().After("gorm:update").Register("trace:after_update", after)
+	callbacks.Delete().Before("gorm:delete").Register("trace:before_delete", before("delete"))
+	callbacks.Delete().After("gorm:delete").Register("trace:after_delete", after)
+	callbacks.RowQuery().Before("gorm:row_query").Register("trace:before_row_query", before("row_query"))
+	callbacks.RowQuery().After("gorm:row_query").Register("trace:after_row_query", after)
+}
+
+func before(operation string) func(*gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		v, ok := scope.Get(gormContextKey)
+		if !ok {
+			return
+		}
+		ctx, ok := v.(context.Context)
+		if !ok || FromContext(ctx) == nil {
+			return
+		}
+		_, span := StartSpan(ctx, "gorm:"+operation, KindClient)
+		span.SetTag("db.system", "postgresql")
+		span.SetTag("db.table", scope.TableName())
+		scope.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func after(scope *gorm.Scope) {
+	v, ok := scope.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := v.(*Span)
+	span.SetTag("db.statement", scope.SQL)
+	span.SetTag("db.rows_affected", strconv.FormatInt(scope.DB().RowsAffected, 10))
+	if scope.HasError() && scope.DB().Error != gorm.ErrRecordNotFound {
+		span.SetError(scope.DB().Error)
+	}
+	span.Finish()
+}
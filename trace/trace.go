@@ -0,0 +1,229 @@
+// Package trace record spans of requests across transport, endpoints and
+// database, and propagate their context by W3C traceparent header. Spans are
+// recorded only when an Exporter is set, see SetExporter.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceID identify a trace, which is all spans of a request
+type TraceID [16]byte
+
+// String return hex of id
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsZero check id is not set, a zero id is invalid
+func (id TraceID) IsZero() bool {
+	return id == TraceID{}
+}
+
+// SpanID identify a span in its trace
+type SpanID [8]byte
+
+// String return hex of id
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsZero check id is not set, a zero id is invalid
+func (id SpanID) IsZero() bool {
+	return id == SpanID{}
+}
+
+// SpanContext is what is propagated to child spans and to other services
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid check both ids of c are set
+func (c SpanContext) IsValid() bool {
+	return !c.TraceID.IsZero() && !c.SpanID.IsZero()
+}
+
+// TraceParent format c as value of traceparent header
+func (c SpanContext) TraceParent() string {
+	flags := "00"
+	if c.Sampled {
+		flags = "01"
+	}
+	return "00-" + c.TraceID.String() + "-" + c.SpanID.String() + "-" + flags
+}
+
+// ParseTraceParent parse value of traceparent header, see
+// https://www.w3.org/TR/trace-context/#traceparent-header
+func ParseTraceParent(s string) (SpanContext, error) {
+	var c SpanContext
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return c, fmt.Errorf("trace: invalid traceparent %q", s)
+	}
+	// version 00 has exactly 4 parts, later versions may append more
+	if parts[0] == "00" && len(parts) != 4 {
+		return c, fmt.Errorf("trace: invalid traceparent %q", s)
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return c, fmt.Errorf("trace: invalid traceparent %q", s)
+	}
+	if _, err := hex.Decode(c.TraceID[:], []byte(parts[1])); err != nil {
+		return c, fmt.Errorf("trace: invalid trace id of traceparent %q", s)
+	}
+	if _, err := hex.Decode(c.SpanID[:], []byte(parts[2])); err != nil {
+		return c, fmt.Errorf("trace: invalid span id of traceparent %q", s)
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return c, fmt.Errorf("trace: invalid flags of traceparent %q", s)
+	}
+	if !c.IsValid() {
+		return c, fmt.Errorf("trace: zero id in traceparent %q", s)
+	}
+	c.Sampled = flags[0]&1 == 1
+	return c, nil
+}
+
+// Kinds of span
+const (
+	KindServer   = "SERVER"
+	KindClient   = "CLIENT"
+	KindInternal = "INTERNAL"
+)
+
+// Span describe an operation of a trace, it is exported when it ends
+type Span struct {
+	Name     string
+	Kind     string
+	Context  SpanContext
+	ParentID SpanID
+	Start    time.Time
+	End      time.Time
+	Tags     map[string]string
+	Err      string
+
+	mu       sync.Mutex
+	ended    bool
+	exporter Exporter
+}
+
+// SetTag set tag key of s to value
+func (s *Span) SetTag(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Tags[key] = value
+	s.mu.Unlock()
+}
+
+// SetError mark s failed by err, a nil err is ignored
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Err = err.Error()
+	s.mu.Unlock()
+}
+
+// Finish end s and export it when it is sampled, only the first call counts
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.mu.Unlock()
+
+	if s.exporter != nil && s.Context.Sampled {
+		s.exporter.Export(s)
+	}
+}
+
+type contextKey int
+
+const (
+	spanKey contextKey = iota
+	remoteKey
+)
+
+// FromContext return span of ctx, it is nil when ctx has none
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey).(*Span)
+	return s
+}
+
+// NewContext return ctx carrying span s
+func NewContext(ctx context.Context, s *Span) context.Context {
+	return context.WithValue(ctx, spanKey, s)
+}
+
+// NewRemoteContext return ctx carrying c which is propagated by a caller,
+// the next span of ctx becomes a child of c
+func NewRemoteContext(ctx context.Context, c SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey, c)
+}
+
+var (
+	exporterMu sync.RWMutex
+	exporter   Exporter
+)
+
+// SetExporter set exporter which spans are given to when they end, a nil
+// exporter stops recording spans
+func SetExporter(e Exporter) {
+	exporterMu.Lock()
+	exporter = e
+	exporterMu.Unlock()
+}
+
+func currentExporter() Exporter {
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+	return exporter
+}
+
+// StartSpan start span name of kind as a child of span of ctx, or of the
+// remote span of ctx, or as root of a new trace. Finish must be called on it
+func StartSpan(ctx context.Context, name, kind string) (context.Context, *Span) {
+	e := currentExporter()
+	s := &Span{
+		Name:     name,
+		Kind:     kind,
+		Start:    time.Now(),
+		Tags:     map[string]string{},
+		exporter: e,
+	}
+
+	var parent SpanContext
+	if p := FromContext(ctx); p != nil {
+		parent = p.Context
+	} else if c, ok := ctx.Value(remoteKey).(SpanContext); ok {
+		parent = c
+	}
+	if parent.IsValid() {
+		s.Context.TraceID = parent.TraceID
+		s.Context.Sampled = parent.Sampled
+		s.ParentID = parent.SpanID
+	} else {
+		rand.Read(s.Context.TraceID[:])
+		s.Context.Sampled = e != nil
+	}
+	rand.Read(s.Context.SpanID[:])
+
+	return NewContext(ctx, s), s
+}
@@ -0,0 +1,117 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type recordExporter struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+func (e *recordExporter) Export(s *Span) {
+	e.mu.Lock()
+	e.spans = append(e.spans, s)
+	e.mu.Unlock()
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		sampled bool
+		wantErr bool
+	}{
+		{"sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, false},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", false, false},
+		{"future version", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, false},
+		{"empty", "", false, true},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, true},
+		{"extra part of version 00", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, true},
+		{"short trace id", "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", false, true},
+		{"not hex", "00-4bf92f3577b34da6a3ce929d0e0e47z-00f067aa0ba902b7-01", false, true},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, true},
+		{"zero span id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseTraceParent(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTraceParent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.Sampled != tt.sampled {
+				t.Errorf("ParseTraceParent() sampled = %v, want %v", c.Sampled, tt.sampled)
+			}
+			if c.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || c.SpanID.String() != "00f067aa0ba902b7" {
+				t.Errorf("ParseTraceParent() = %v", c.TraceParent())
+			}
+		})
+	}
+}
+
+func TestSpanContext_TraceParent(t *testing.T) {
+	want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	c, err := ParseTraceParent(want)
+	if err != nil {
+		t.Fatalf("ParseTraceParent() error = %v", err)
+	}
+	if got := c.TraceParent(); got != want {
+		t.Errorf("SpanContext.TraceParent() = %v, want %v", got, want)
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	e := &recordExporter{}
+	SetExporter(e)
+	defer SetExporter(nil)
+
+	remote, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := NewRemoteContext(context.Background(), remote)
+
+	ctx, server := StartSpan(ctx, "HTTP GET /books", KindServer)
+	_, child := StartSpan(ctx, "FindBook", KindInternal)
+	child.SetError(errors.New("not found"))
+	child.Finish()
+	child.Finish()
+	server.Finish()
+
+	if server.Context.TraceID != remote.TraceID || server.ParentID != remote.SpanID {
+		t.Errorf("StartSpan() server span is not a child of remote span")
+	}
+	if child.Context.TraceID != remote.TraceID || child.ParentID != server.Context.SpanID {
+		t.Errorf("StartSpan() child span is not a child of server span")
+	}
+	if len(e.spans) != 2 || e.spans[0] != child || e.spans[1] != server {
+		t.Fatalf("Exporter got %d spans, want child and server once", len(e.spans))
+	}
+	if child.Err != "not found" {
+		t.Errorf("Span.Err = %q, want %q", child.Err, "not found")
+	}
+}
+
+func TestStartSpan_notSampled(t *testing.T) {
+	e := &recordExporter{}
+	SetExporter(e)
+	defer SetExporter(nil)
+
+	remote, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	_, span := StartSpan(NewRemoteContext(context.Background(), remote), "HTTP GET /books", KindServer)
+	span.Finish()
+
+	if len(e.spans) != 0 {
+		t.Errorf("Exporter got %d spans of a trace which is not sampled", len(e.spans))
+	}
+}
+
+func TestSpan_nil(t *testing.T) {
+	var s *Span
+	s.SetTag("key", "value")
+	s.SetError(errors.New("error"))
+	s.Finish()
+}